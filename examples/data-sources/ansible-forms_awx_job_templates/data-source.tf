@@ -0,0 +1,19 @@
+data "ansible-forms_awx_job_templates" "all" {
+  cx_profile_name = "cluster1"
+}
+
+resource "ansible-forms_form" "create_share" {
+  cx_profile_name = "cluster1"
+  name            = "Create Share"
+  type            = "awx"
+  template        = "Create Share"
+  categories      = ["Storage"]
+  roles           = ["admin"]
+
+  lifecycle {
+    precondition {
+      condition     = contains(data.ansible-forms_awx_job_templates.all.names, "Create Share")
+      error_message = "AWX job template Create Share does not exist."
+    }
+  }
+}
@@ -0,0 +1,7 @@
+data "ansible-forms_credentials" "all" {
+  cx_profile_name = "cluster1"
+}
+
+output "ontap_cred_exists" {
+  value = contains(data.ansible-forms_credentials.all.names, "ontap_cred")
+}
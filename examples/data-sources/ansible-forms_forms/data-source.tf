@@ -0,0 +1,9 @@
+data "ansible-forms_forms" "storage" {
+  cx_profile_name = "cluster1"
+  category        = "Storage"
+  name_regex      = "Share$"
+}
+
+output "storage_forms" {
+  value = [for form in data.ansible-forms_forms.storage.forms : form.name]
+}
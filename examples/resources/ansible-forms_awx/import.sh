@@ -0,0 +1,2 @@
+# Import the AWX configuration with the connection profile name
+terraform import ansible-forms_awx.awx cluster1
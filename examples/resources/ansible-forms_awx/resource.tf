@@ -0,0 +1,9 @@
+resource "ansible-forms_awx" "awx" {
+  cx_profile_name = "cluster1"
+  uri             = "https://awx.example.com"
+  token           = var.awx_token
+  # increase after a token rotation, to send the new token
+  token_version = 1
+  # the CA certificates are only needed when AWX uses a private CA
+  ca_bundle = file("${path.module}/ca.pem")
+}
@@ -0,0 +1,4 @@
+variable "awx_token" {
+  type      = string
+  sensitive = true
+}
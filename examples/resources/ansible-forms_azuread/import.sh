@@ -0,0 +1,2 @@
+# Import the Azure AD configuration with the connection profile name
+terraform import ansible-forms_azuread.sso cluster1
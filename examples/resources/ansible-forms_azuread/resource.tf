@@ -0,0 +1,8 @@
+resource "ansible-forms_azuread" "sso" {
+  cx_profile_name = "cluster1"
+  client_id       = "6f1c2a4e-0000-4000-8000-000000000001"
+  client_secret   = var.azuread_client_secret
+  # increase after a secret rotation, to send the new secret
+  client_secret_version = 1
+  group_filter          = "^forms_"
+}
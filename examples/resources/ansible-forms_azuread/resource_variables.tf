@@ -0,0 +1,4 @@
+variable "azuread_client_secret" {
+  type      = string
+  sensitive = true
+}
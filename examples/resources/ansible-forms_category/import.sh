@@ -0,0 +1,2 @@
+# Import a category with the format: name,cx_profile_name
+terraform import ansible-forms_category.storage "Storage,cluster1"
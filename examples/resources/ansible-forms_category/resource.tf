@@ -0,0 +1,9 @@
+resource "ansible-forms_category" "storage" {
+  cx_profile_name = "cluster1"
+  name            = "Storage"
+  icon            = "database"
+  categories = [
+    { name = "NetApp", icon = "server" },
+    { name = "Backup" },
+  ]
+}
@@ -0,0 +1,2 @@
+# Import a credential with the format: name,cx_profile_name
+terraform import ansible-forms_credential.ontap_cred "ontap_cred,cluster1"
@@ -0,0 +1,11 @@
+resource "ansible-forms_credential" "ontap_cred" {
+  cx_profile_name = "cluster1"
+  name            = "ontap_cred"
+  host            = "ontap1.example.com"
+  user            = "admin"
+  password        = var.ontap_password
+  # increase after a password rotation, to send the new password
+  password_version = 1
+  description      = "ONTAP cluster admin"
+  secure           = true
+}
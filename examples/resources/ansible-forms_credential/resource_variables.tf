@@ -0,0 +1,4 @@
+variable "ontap_password" {
+  type      = string
+  sensitive = true
+}
@@ -0,0 +1,2 @@
+# Import a form with the format: name,cx_profile_name
+terraform import ansible-forms_form.create_share "Create Share,cluster1"
@@ -0,0 +1 @@
+../../provider/provider.tf
\ No newline at end of file
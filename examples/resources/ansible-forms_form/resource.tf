@@ -0,0 +1,24 @@
+resource "ansible-forms_form" "create_share" {
+  cx_profile_name = "cluster1"
+  name            = "Create Share"
+  description     = "Creates a CIFS share"
+  type            = "ansible"
+  playbook        = "create_share.yaml"
+  categories      = ["Storage"]
+  roles           = ["admin"]
+  approval = {
+    title = "Approve the share"
+    roles = ["admin"]
+  }
+  fields = jsonencode([
+    { name = "share_name", type = "text", label = "Share name", required = true },
+    { name = "size", type = "number", label = "Size (GB)", default = 10 },
+  ])
+}
+
+# the same form, kept in a YAML file as found in forms.yaml
+resource "ansible-forms_form" "delete_share" {
+  cx_profile_name = "cluster1"
+  name            = "Delete Share"
+  definition      = file("${path.module}/delete_share.yaml")
+}
@@ -0,0 +1,2 @@
+# Import the forms configuration with the connection profile name
+terraform import ansible-forms_forms_config.config cluster1
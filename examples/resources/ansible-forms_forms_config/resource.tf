@@ -0,0 +1,5 @@
+# the complete forms configuration, kept in forms.yaml next to the module
+resource "ansible-forms_forms_config" "config" {
+  cx_profile_name = "cluster1"
+  config          = file("${path.module}/forms.yaml")
+}
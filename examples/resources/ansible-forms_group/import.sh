@@ -0,0 +1,2 @@
+# Import a group with the format: name,cx_profile_name
+terraform import ansible-forms_group.storage "storage,cluster1"
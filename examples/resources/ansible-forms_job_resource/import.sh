@@ -0,0 +1,2 @@
+# Import a job with the format: id,cx_profile_name
+terraform import ansible-forms_job_resource.job 12,cluster1
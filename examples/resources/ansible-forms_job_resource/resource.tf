@@ -1,6 +1,8 @@
 resource "ansible-forms_job_resource" "job" {
   cx_profile_name = "cluster1"
   form_name       = "Demo Form Ansible No input"
+  # the form has no fields, the extravars are only passed to the playbook
+  skip_form_validation = true
   extravars = {
     name                = "github.com/dsha256"
     region              = "myregion"
@@ -0,0 +1,2 @@
+# Import the LDAP configuration with the connection profile name
+terraform import ansible-forms_ldap.ldap cluster1
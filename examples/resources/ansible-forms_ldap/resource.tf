@@ -0,0 +1,17 @@
+resource "ansible-forms_ldap" "ldap" {
+  cx_profile_name = "cluster1"
+  server          = "dc1.example.com"
+  port            = 636
+  enable_tls      = true
+  # the CA certificate is only needed when it is not trusted by Ansible Forms
+  ca_cert       = file("${path.module}/ca.pem")
+  bind_user_dn  = "cn=ansibleforms,ou=services,dc=example,dc=com"
+  bind_password = var.ldap_bind_password
+  # increase after a password rotation, to send the new password
+  bind_password_version = 1
+  search_base           = "dc=example,dc=com"
+}
+
+output "ldap_connection_test" {
+  value = ansible-forms_ldap.ldap.connection_test
+}
@@ -0,0 +1,4 @@
+variable "ldap_bind_password" {
+  type      = string
+  sensitive = true
+}
@@ -0,0 +1,2 @@
+# Import the OIDC configuration with the connection profile name
+terraform import ansible-forms_oidc.sso cluster1
@@ -0,0 +1,9 @@
+resource "ansible-forms_oidc" "sso" {
+  cx_profile_name = "cluster1"
+  issuer          = "https://login.example.com/realms/prod"
+  client_id       = "ansible-forms"
+  client_secret   = var.oidc_client_secret
+  # increase after a secret rotation, to send the new secret
+  client_secret_version = 1
+  group_filter          = "^forms_"
+}
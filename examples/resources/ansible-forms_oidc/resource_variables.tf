@@ -0,0 +1,4 @@
+variable "oidc_client_secret" {
+  type      = string
+  sensitive = true
+}
@@ -0,0 +1,2 @@
+# Import a repository with the format: name,cx_profile_name
+terraform import ansible-forms_repository.playbooks "playbooks,cluster1"
@@ -0,0 +1,23 @@
+resource "ansible-forms_credential" "git" {
+  cx_profile_name = "cluster1"
+  name            = "git_cred"
+  host            = "git.example.com"
+  user            = "ansible-forms"
+  password        = var.git_token
+}
+
+resource "ansible-forms_repository" "playbooks" {
+  cx_profile_name   = "cluster1"
+  name              = "playbooks"
+  uri               = "https://git.example.com/automation/playbooks.git"
+  branch            = "main"
+  credential        = ansible-forms_credential.git.name
+  use_for_playbooks = true
+  cron              = "*/15 * * * *"
+  # pull when a new release is deployed, use timestamp() to pull on every apply
+  pull_on_apply = var.playbooks_release
+}
+
+output "playbooks_commit" {
+  value = ansible-forms_repository.playbooks.last_commit
+}
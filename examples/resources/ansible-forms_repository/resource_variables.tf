@@ -0,0 +1,9 @@
+variable "git_token" {
+  type      = string
+  sensitive = true
+}
+
+variable "playbooks_release" {
+  type    = string
+  default = "v1.0.0"
+}
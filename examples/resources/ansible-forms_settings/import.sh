@@ -0,0 +1,2 @@
+# Import the settings with the connection profile name
+terraform import ansible-forms_settings.settings cluster1
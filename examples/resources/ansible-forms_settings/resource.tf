@@ -0,0 +1,14 @@
+# only the settings below are managed, the other settings keep their current value
+resource "ansible-forms_settings" "settings" {
+  cx_profile_name = "cluster1"
+  url             = "https://forms.example.com"
+  mail_server     = "smtp.example.com"
+  mail_port       = 587
+  mail_secure     = true
+  mail_username   = "ansible-forms"
+  mail_password   = var.smtp_password
+  # increase after a password rotation, to send the new password
+  mail_password_version = 1
+  mail_from             = "ansible-forms@example.com"
+  job_retention_days    = 90
+}
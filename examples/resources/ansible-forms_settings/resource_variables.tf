@@ -0,0 +1,4 @@
+variable "smtp_password" {
+  type      = string
+  sensitive = true
+}
@@ -0,0 +1,2 @@
+# Import a user with the format: username,cx_profile_name
+terraform import ansible-forms_user.jdoe "jdoe,cluster1"
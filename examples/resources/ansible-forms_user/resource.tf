@@ -0,0 +1,14 @@
+resource "ansible-forms_group" "storage" {
+  cx_profile_name = "cluster1"
+  name            = "storage"
+}
+
+resource "ansible-forms_user" "jdoe" {
+  cx_profile_name = "cluster1"
+  username        = "jdoe"
+  email           = "jdoe@example.com"
+  password        = var.jdoe_password
+  # increase to reset the password
+  password_version = 1
+  group_id         = ansible-forms_group.storage.id
+}
@@ -0,0 +1,4 @@
+variable "jdoe_password" {
+  type      = string
+  sensitive = true
+}
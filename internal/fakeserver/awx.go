@@ -0,0 +1,91 @@
+package fakeserver
+
+import (
+	"net/http"
+	"sort"
+)
+
+// AWX is the AWX connection configuration of Ansible Forms. The token and the password are never returned.
+type AWX struct {
+	URI            string `json:"uri"`
+	Token          string `json:"token,omitempty"`
+	Username       string `json:"username"`
+	Password       string `json:"password,omitempty"`
+	UseCredentials bool   `json:"use_credentials"`
+	IgnoreCerts    bool   `json:"ignore_certs"`
+	CABundle       string `json:"ca_bundle"`
+}
+
+// JobTemplate is an AWX job template, as listed through Ansible Forms.
+type JobTemplate struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// AWX returns the AWX configuration, including the token and the password.
+func (s *Server) AWX() AWX {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.awx
+}
+
+// ReplaceAWX replaces the AWX configuration, as a change made outside of the provider.
+func (s *Server) ReplaceAWX(awx AWX) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.awx = awx
+}
+
+// AddJobTemplate adds an AWX job template, and returns its id.
+func (s *Server) AddJobTemplate(template JobTemplate) int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	template.ID = s.newID()
+	s.jobTemplates = append(s.jobTemplates, template)
+	return template.ID
+}
+
+func (s *Server) registerAWX(mux *http.ServeMux) {
+	s.handle(mux, "GET awx", s.getAWX)
+	s.handle(mux, "PUT awx", s.updateAWX)
+	s.handle(mux, "GET awx/jobtemplates", s.listJobTemplates)
+}
+
+func (s *Server) getAWX(w http.ResponseWriter, _ *http.Request) {
+	awx := s.awx
+	awx.Token = ""
+	awx.Password = ""
+	writeSuccess(w, "", awx)
+}
+
+func (s *Server) updateAWX(w http.ResponseWriter, r *http.Request) {
+	update := s.awx
+	if !decodeBody(w, r, &update) {
+		return
+	}
+	if update.URI == "" {
+		writeError(w, http.StatusBadRequest, "failed to update awx", "uri is required")
+		return
+	}
+	// the token and the password are only changed when they are provided
+	if update.Token == "" {
+		update.Token = s.awx.Token
+	}
+	if update.Password == "" {
+		update.Password = s.awx.Password
+	}
+	s.awx = update
+	writeSuccess(w, "awx updated", "")
+}
+
+// listJobTemplates lists the job templates, which requires AWX to be configured.
+func (s *Server) listJobTemplates(w http.ResponseWriter, _ *http.Request) {
+	if s.awx.URI == "" {
+		writeError(w, http.StatusBadRequest, "failed to get job templates", "awx is not configured")
+		return
+	}
+	templates := append([]JobTemplate{}, s.jobTemplates...)
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	writeSuccess(w, "", templates)
+}
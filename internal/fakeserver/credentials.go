@@ -0,0 +1,161 @@
+package fakeserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// Credential is a credential stored in Ansible Forms. The password is never returned.
+type Credential struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Host        string `json:"host"`
+	User        string `json:"user"`
+	Password    string `json:"password,omitempty"`
+	Description string `json:"description"`
+	Secure      bool   `json:"secure"`
+}
+
+// AddCredential adds a credential, and returns its id.
+func (s *Server) AddCredential(credential Credential) int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	credential.ID = s.newID()
+	s.credentials[credential.ID] = &credential
+	return credential.ID
+}
+
+// ReplaceCredential replaces the credential with the same id, as a change made outside of the provider.
+func (s *Server) ReplaceCredential(credential Credential) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.credentials[credential.ID] = &credential
+}
+
+// Credential returns a credential, including its password, and whether it exists.
+func (s *Server) Credential(id int64) (Credential, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	credential, ok := s.credentials[id]
+	if !ok {
+		return Credential{}, false
+	}
+	return *credential, true
+}
+
+// CredentialByName returns a credential, including its password, and whether it exists.
+func (s *Server) CredentialByName(name string) (Credential, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	credential, ok := s.credentialByName(name)
+	if !ok {
+		return Credential{}, false
+	}
+	return *credential, true
+}
+
+func (s *Server) registerCredentials(mux *http.ServeMux) {
+	s.handle(mux, "GET credential/{$}", s.listCredentials)
+	s.handle(mux, "POST credential/{$}", s.createCredential)
+	s.handle(mux, "GET credential/{id}", s.getCredential)
+	s.handle(mux, "PUT credential/{id}", s.updateCredential)
+	s.handle(mux, "DELETE credential/{id}", s.deleteCredential)
+}
+
+// credentialByName returns the credential with name, and whether it exists.
+func (s *Server) credentialByName(name string) (*Credential, bool) {
+	for _, credential := range s.credentials {
+		if credential.Name == name {
+			return credential, true
+		}
+	}
+	return nil, false
+}
+
+// withoutPassword returns a copy of credential, as reported by Ansible Forms.
+func withoutPassword(credential *Credential) Credential {
+	result := *credential
+	result.Password = ""
+	return result
+}
+
+func (s *Server) listCredentials(w http.ResponseWriter, _ *http.Request) {
+	credentials := []Credential{}
+	for _, credential := range s.credentials {
+		credentials = append(credentials, withoutPassword(credential))
+	}
+	sort.Slice(credentials, func(i, j int) bool { return credentials[i].ID < credentials[j].ID })
+	writeSuccess(w, "", credentials)
+}
+
+// credentialFromPath returns the credential identified by the id path value, and reports a 404 error when it does not exist.
+func (s *Server) credentialFromPath(w http.ResponseWriter, r *http.Request) (*Credential, bool) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return nil, false
+	}
+	credential, ok := s.credentials[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "credential not found", fmt.Sprintf("no credential found with id %d", id))
+		return nil, false
+	}
+	return credential, true
+}
+
+func (s *Server) createCredential(w http.ResponseWriter, r *http.Request) {
+	var credential Credential
+	if !decodeBody(w, r, &credential) {
+		return
+	}
+	if credential.Name == "" {
+		writeError(w, http.StatusBadRequest, "failed to create credential", "name is required")
+		return
+	}
+	if _, exists := s.credentialByName(credential.Name); exists {
+		writeError(w, http.StatusConflict, "failed to create credential", fmt.Sprintf("credential '%s' already exists", credential.Name))
+		return
+	}
+	credential.ID = s.newID()
+	s.credentials[credential.ID] = &credential
+	writeSuccess(w, "credential created", map[string]any{"id": credential.ID})
+}
+
+func (s *Server) getCredential(w http.ResponseWriter, r *http.Request) {
+	credential, ok := s.credentialFromPath(w, r)
+	if !ok {
+		return
+	}
+	writeSuccess(w, "", withoutPassword(credential))
+}
+
+func (s *Server) updateCredential(w http.ResponseWriter, r *http.Request) {
+	credential, ok := s.credentialFromPath(w, r)
+	if !ok {
+		return
+	}
+	update := *credential
+	if !decodeBody(w, r, &update) {
+		return
+	}
+	if other, exists := s.credentialByName(update.Name); exists && other.ID != credential.ID {
+		writeError(w, http.StatusConflict, "failed to update credential", fmt.Sprintf("credential '%s' already exists", update.Name))
+		return
+	}
+	// the password is only changed when it is provided
+	if update.Password == "" {
+		update.Password = credential.Password
+	}
+	update.ID = credential.ID
+	*credential = update
+	writeSuccess(w, "credential updated", "")
+}
+
+func (s *Server) deleteCredential(w http.ResponseWriter, r *http.Request) {
+	credential, ok := s.credentialFromPath(w, r)
+	if !ok {
+		return
+	}
+	delete(s.credentials, credential.ID)
+	writeSuccess(w, "credential deleted", "")
+}
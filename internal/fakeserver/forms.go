@@ -0,0 +1,214 @@
+package fakeserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Form is a form definition, as found in the forms configuration.
+type Form struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Type        string           `json:"type,omitempty"`
+	Playbook    string           `json:"playbook,omitempty"`
+	Template    string           `json:"template,omitempty"`
+	Categories  []string         `json:"categories,omitempty"`
+	Roles       []string         `json:"roles,omitempty"`
+	Image       string           `json:"image,omitempty"`
+	Help        string           `json:"help,omitempty"`
+	Approval    *Approval        `json:"approval,omitempty"`
+	Fields      []map[string]any `json:"fields,omitempty"`
+	// Outcome is the final status of the jobs launched with this form, JobStatusSuccess when empty.
+	// It is not part of the Ansible Forms configuration.
+	Outcome string `json:"-"`
+}
+
+// Approval is the approval configuration of a form. Jobs for a form with an approval wait for Approve or Reject.
+type Approval struct {
+	Title   string   `json:"title,omitempty"`
+	Message string   `json:"message,omitempty"`
+	Roles   []string `json:"roles,omitempty"`
+}
+
+// defaultConfig returns the sections of a new forms configuration, other than forms.
+func defaultConfig() map[string]any {
+	return map[string]any{
+		"categories": []any{map[string]any{"name": "Default", "icon": "bars"}},
+		"roles": []any{
+			map[string]any{"name": "admin", "groups": []any{"local/admins"}},
+			map[string]any{"name": "public", "groups": []any{}},
+		},
+		"constants": map[string]any{},
+	}
+}
+
+// AddForm adds or replaces a form.
+func (s *Server) AddForm(form Form) {
+	document, err := toDocument(form)
+	if err != nil {
+		panic(err)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.outcomes[form.Name] = form.Outcome
+	for index, existing := range s.forms {
+		if existing["name"] == form.Name {
+			s.forms[index] = document
+			return
+		}
+	}
+	s.forms = append(s.forms, document)
+}
+
+// FormDefinition returns a form as found in the forms configuration, and whether it exists.
+// Keys that are not known to Form are kept.
+func (s *Server) FormDefinition(name string) (map[string]any, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, document := range s.forms {
+		if document["name"] == name {
+			return document, true
+		}
+	}
+	return nil, false
+}
+
+// CategoryDefinition returns a top level category as found in the forms configuration, and whether it exists.
+func (s *Server) CategoryDefinition(name string) (map[string]any, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	categories, _ := s.config["categories"].([]any)
+	for _, element := range categories {
+		if document, ok := element.(map[string]any); ok && document["name"] == name {
+			return document, true
+		}
+	}
+	return nil, false
+}
+
+// ConfigSection returns a section of the forms configuration, eg categories.
+func (s *Server) ConfigSection(name string) any {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.config[name]
+}
+
+// form returns the form with name, and whether it exists.
+func (s *Server) form(name string) (Form, bool) {
+	for _, document := range s.forms {
+		if document["name"] != name {
+			continue
+		}
+		var form Form
+		data, _ := json.Marshal(document)
+		if err := json.Unmarshal(data, &form); err != nil {
+			return Form{}, false
+		}
+		form.Outcome = s.outcomes[name]
+		return form, true
+	}
+	return Form{}, false
+}
+
+func (s *Server) getConfig(w http.ResponseWriter, _ *http.Request) {
+	output := make(map[string]any, len(s.config)+1)
+	for key, value := range s.config {
+		output[key] = value
+	}
+	output["forms"] = append([]map[string]any{}, s.forms...)
+	writeSuccess(w, "", output)
+}
+
+// putConfig replaces the forms configuration, forms must have a unique name.
+func (s *Server) putConfig(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	forms, err := checkConfig(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid forms configuration", err.Error())
+		return
+	}
+	delete(body, "forms")
+	s.config = body
+	s.forms = forms
+	writeSuccess(w, "forms configuration saved", "")
+}
+
+// validateConfig checks a forms configuration without saving it.
+func (s *Server) validateConfig(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if _, err := checkConfig(body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid forms configuration", err.Error())
+		return
+	}
+	writeSuccess(w, "forms configuration is valid", "")
+}
+
+// backupConfig saves a copy of the forms configuration, and returns the backup name.
+func (s *Server) backupConfig(w http.ResponseWriter, _ *http.Request) {
+	backup := make(map[string]any, len(s.config)+1)
+	for key, value := range s.config {
+		backup[key] = value
+	}
+	backup["forms"] = append([]map[string]any{}, s.forms...)
+	name := fmt.Sprintf("forms.yaml.%s.%d", s.now().UTC().Format("20060102150405"), len(s.backups)+1)
+	s.backups[name] = backup
+	writeSuccess(w, "forms configuration backed up", map[string]any{"backup": name})
+}
+
+// Backup returns a backup of the forms configuration, and whether it exists.
+func (s *Server) Backup(name string) (map[string]any, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	backup, ok := s.backups[name]
+	return backup, ok
+}
+
+// checkConfig returns the forms of a forms configuration, forms and top level categories must be objects with a
+// unique name.
+func checkConfig(config map[string]any) ([]map[string]any, error) {
+	if categories, ok := config["categories"].([]any); ok {
+		names := map[string]bool{}
+		for _, element := range categories {
+			document, ok := element.(map[string]any)
+			name, _ := document["name"].(string)
+			if !ok || name == "" || names[name] {
+				return nil, fmt.Errorf("categories must be objects with a unique name")
+			}
+			names[name] = true
+		}
+	}
+	list, ok := config["forms"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("forms must be a list")
+	}
+	forms := make([]map[string]any, 0, len(list))
+	names := map[string]bool{}
+	for _, element := range list {
+		document, ok := element.(map[string]any)
+		name, _ := document["name"].(string)
+		if !ok || name == "" || names[name] {
+			return nil, fmt.Errorf("forms must be objects with a unique name")
+		}
+		names[name] = true
+		forms = append(forms, document)
+	}
+	return forms, nil
+}
+
+// toDocument returns v as a JSON document.
+func toDocument(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var document map[string]any
+	err = json.Unmarshal(data, &document)
+	return document, err
+}
@@ -0,0 +1,141 @@
+package fakeserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// Group is a local Ansible Forms group, that users belong to and roles refer to as local/<name>.
+type Group struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// AddGroup adds a group, and returns its id.
+func (s *Server) AddGroup(group Group) int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	group.ID = s.newID()
+	s.groups[group.ID] = &group
+	return group.ID
+}
+
+// GroupByName returns a group, and whether it exists.
+func (s *Server) GroupByName(name string) (Group, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	group, ok := s.groupByName(name)
+	if !ok {
+		return Group{}, false
+	}
+	return *group, true
+}
+
+// ReplaceGroup replaces the group with the same id, as a change made outside of the provider.
+func (s *Server) ReplaceGroup(group Group) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.groups[group.ID] = &group
+}
+
+func (s *Server) registerGroups(mux *http.ServeMux) {
+	s.handle(mux, "GET group/{$}", s.listGroups)
+	s.handle(mux, "POST group/{$}", s.createGroup)
+	s.handle(mux, "GET group/{id}", s.getGroup)
+	s.handle(mux, "PUT group/{id}", s.updateGroup)
+	s.handle(mux, "DELETE group/{id}", s.deleteGroup)
+}
+
+// groupByName returns the group with name, and whether it exists.
+func (s *Server) groupByName(name string) (*Group, bool) {
+	for _, group := range s.groups {
+		if group.Name == name {
+			return group, true
+		}
+	}
+	return nil, false
+}
+
+func (s *Server) listGroups(w http.ResponseWriter, _ *http.Request) {
+	groups := []Group{}
+	for _, group := range s.groups {
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].ID < groups[j].ID })
+	writeSuccess(w, "", groups)
+}
+
+// groupFromPath returns the group identified by the id path value, and reports a 404 error when it does not exist.
+func (s *Server) groupFromPath(w http.ResponseWriter, r *http.Request) (*Group, bool) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return nil, false
+	}
+	group, ok := s.groups[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "group not found", fmt.Sprintf("no group found with id %d", id))
+		return nil, false
+	}
+	return group, true
+}
+
+func (s *Server) createGroup(w http.ResponseWriter, r *http.Request) {
+	var group Group
+	if !decodeBody(w, r, &group) {
+		return
+	}
+	if group.Name == "" {
+		writeError(w, http.StatusBadRequest, "failed to create group", "name is required")
+		return
+	}
+	if _, exists := s.groupByName(group.Name); exists {
+		writeError(w, http.StatusConflict, "failed to create group", fmt.Sprintf("group '%s' already exists", group.Name))
+		return
+	}
+	group.ID = s.newID()
+	s.groups[group.ID] = &group
+	writeSuccess(w, "group created", map[string]any{"id": group.ID})
+}
+
+func (s *Server) getGroup(w http.ResponseWriter, r *http.Request) {
+	group, ok := s.groupFromPath(w, r)
+	if !ok {
+		return
+	}
+	writeSuccess(w, "", *group)
+}
+
+func (s *Server) updateGroup(w http.ResponseWriter, r *http.Request) {
+	group, ok := s.groupFromPath(w, r)
+	if !ok {
+		return
+	}
+	update := *group
+	if !decodeBody(w, r, &update) {
+		return
+	}
+	if other, exists := s.groupByName(update.Name); exists && other.ID != group.ID {
+		writeError(w, http.StatusConflict, "failed to update group", fmt.Sprintf("group '%s' already exists", update.Name))
+		return
+	}
+	update.ID = group.ID
+	*group = update
+	writeSuccess(w, "group updated", "")
+}
+
+// deleteGroup deletes a group without users, as the users reference their group.
+func (s *Server) deleteGroup(w http.ResponseWriter, r *http.Request) {
+	group, ok := s.groupFromPath(w, r)
+	if !ok {
+		return
+	}
+	for _, user := range s.users {
+		if user.GroupID == group.ID {
+			writeError(w, http.StatusConflict, "failed to delete group", fmt.Sprintf("group '%s' still has users", group.Name))
+			return
+		}
+	}
+	delete(s.groups, group.ID)
+	writeSuccess(w, "group deleted", "")
+}
@@ -0,0 +1,290 @@
+package fakeserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Ansible Forms job statuses.
+const (
+	JobStatusQueued          = "queued"
+	JobStatusRunning         = "running"
+	JobStatusSuccess         = "success"
+	JobStatusFailed          = "failed"
+	JobStatusAborted         = "aborted"
+	JobStatusWaitingApproval = "approve"
+	JobStatusRejected        = "rejected"
+)
+
+// job is a job as stored by the server.
+type job struct {
+	id          int64
+	form        Form
+	extravars   map[string]any
+	credentials map[string]any
+	user        string
+	// state is the final status, or JobStatusWaitingApproval, or empty while the job is queued or running
+	state   string
+	started time.Time
+	start   time.Time
+	end     time.Time
+}
+
+// JobStatus returns the current status of a job, and whether the job exists.
+func (s *Server) JobStatus(id int64) (string, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	j, ok := s.jobs[id]
+	if !ok {
+		return "", false
+	}
+	return s.status(j), true
+}
+
+// JobExtravars returns the extravars a job was launched with, and whether the job exists.
+func (s *Server) JobExtravars(id int64) (map[string]any, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	j, ok := s.jobs[id]
+	if !ok {
+		return nil, false
+	}
+	extravars := make(map[string]any, len(j.extravars))
+	for key, value := range j.extravars {
+		extravars[key] = value
+	}
+	return extravars, true
+}
+
+// JobIDs returns the ids of the existing jobs, in creation order.
+func (s *Server) JobIDs() []int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	ids := make([]int64, 0, len(s.jobs))
+	for id := range s.jobs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// AddJob adds a successful job of the form formName, as launched outside of Terraform, and returns its id.
+// The form does not need to exist.
+func (s *Server) AddJob(formName string, extravars map[string]any, credentials map[string]any) int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	form, ok := s.form(formName)
+	if !ok {
+		form = Form{Name: formName, Type: "ansible"}
+	}
+	now := s.now()
+	j := &job{
+		id:          s.newID(),
+		form:        form,
+		extravars:   extravars,
+		credentials: credentials,
+		user:        s.Username,
+		state:       JobStatusSuccess,
+		started:     now,
+		start:       now,
+		end:         now,
+	}
+	s.jobs[j.id] = j
+	return j.id
+}
+
+// DeleteJob deletes a job, eg to simulate a job deleted outside of Terraform.
+func (s *Server) DeleteJob(id int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.jobs, id)
+}
+
+func (s *Server) registerJobs(mux *http.ServeMux) {
+	s.handle(mux, "GET job/{$}", s.listJobs)
+	s.handle(mux, "POST job/{$}", s.createJob)
+	s.handle(mux, "GET job/{id}", s.getJob)
+	s.handle(mux, "DELETE job/{id}", s.deleteJob)
+	s.handle(mux, "POST job/{id}/approve", s.approveJob)
+	s.handle(mux, "POST job/{id}/reject", s.rejectJob)
+	s.handle(mux, "POST job/{id}/abort", s.abortJob)
+}
+
+// status returns the status of j, and moves it to its final status when QueueTime and RunTime have elapsed.
+func (s *Server) status(j *job) string {
+	if j.state != "" {
+		return j.state
+	}
+	elapsed := s.now().Sub(j.started)
+	switch {
+	case elapsed < s.QueueTime:
+		return JobStatusQueued
+	case elapsed < s.QueueTime+s.RunTime:
+		return JobStatusRunning
+	}
+	j.state = j.form.Outcome
+	if j.state == "" {
+		j.state = JobStatusSuccess
+	}
+	j.end = s.now()
+	return j.state
+}
+
+// record returns the job as reported by Ansible Forms, extravars and credentials are JSON strings.
+func (s *Server) record(j *job) map[string]any {
+	status := s.status(j)
+	extravars, _ := json.Marshal(j.extravars)
+	credentials, _ := json.Marshal(j.credentials)
+	var output []string
+	switch status {
+	case JobStatusSuccess:
+		output = []string{"PLAY [localhost] ****", "TASK [demo] ****", "ok: [localhost]", "PLAY RECAP ****"}
+	case JobStatusFailed:
+		output = []string{"PLAY [localhost] ****", "TASK [demo] ****", "fatal: [localhost]: FAILED!", "PLAY RECAP ****"}
+	case JobStatusRunning:
+		output = []string{"PLAY [localhost] ****"}
+	}
+	record := map[string]any{
+		"id":            j.id,
+		"formName":      j.form.Name,
+		"target":        j.form.Playbook + j.form.Template,
+		"status":        status,
+		"start":         j.start.UTC().Format(time.RFC3339),
+		"end":           "",
+		"user":          j.user,
+		"user_type":     "local",
+		"job_type":      j.form.Type,
+		"extravars":     string(extravars),
+		"credentials":   string(credentials),
+		"output":        strings.Join(output, "\n"),
+		"counter":       len(output),
+		"no_of_records": len(output),
+		"approval":      "",
+	}
+	if !j.end.IsZero() {
+		record["end"] = j.end.UTC().Format(time.RFC3339)
+	}
+	if j.form.Approval != nil {
+		approval, _ := json.Marshal(j.form.Approval)
+		record["approval"] = string(approval)
+	}
+	return record
+}
+
+func (s *Server) listJobs(w http.ResponseWriter, _ *http.Request) {
+	records := []map[string]any{}
+	for _, j := range s.jobs {
+		records = append(records, s.record(j))
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i]["id"].(int64) < records[j]["id"].(int64) })
+	writeSuccess(w, "", records)
+}
+
+func (s *Server) createJob(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		FormName    string         `json:"formName"`
+		Extravars   map[string]any `json:"extravars"`
+		Credentials map[string]any `json:"credentials"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	form, ok := s.form(body.FormName)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "failed to launch job", fmt.Sprintf("form '%s' not found", body.FormName))
+		return
+	}
+	now := s.now()
+	j := &job{
+		id:          s.newID(),
+		form:        form,
+		extravars:   body.Extravars,
+		credentials: body.Credentials,
+		user:        s.Username,
+		started:     now,
+		start:       now,
+	}
+	if form.Approval != nil {
+		j.state = JobStatusWaitingApproval
+	}
+	s.jobs[j.id] = j
+	writeSuccess(w, form.Type+" job launched", map[string]any{"id": j.id})
+}
+
+// jobFromPath returns the job identified by the id path value, and reports a 404 error when it does not exist.
+func (s *Server) jobFromPath(w http.ResponseWriter, r *http.Request) (*job, bool) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return nil, false
+	}
+	j, ok := s.jobs[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "job not found", fmt.Sprintf("no job found with id %d", id))
+		return nil, false
+	}
+	return j, true
+}
+
+func (s *Server) getJob(w http.ResponseWriter, r *http.Request) {
+	j, ok := s.jobFromPath(w, r)
+	if !ok {
+		return
+	}
+	// the job is returned as data, without the output/error wrapper
+	writeJSON(w, http.StatusOK, map[string]any{"status": "success", "message": "job found", "data": s.record(j)})
+}
+
+func (s *Server) deleteJob(w http.ResponseWriter, r *http.Request) {
+	j, ok := s.jobFromPath(w, r)
+	if !ok {
+		return
+	}
+	delete(s.jobs, j.id)
+	writeSuccess(w, "job deleted", "")
+}
+
+func (s *Server) approveJob(w http.ResponseWriter, r *http.Request) {
+	j, ok := s.jobFromPath(w, r)
+	if !ok {
+		return
+	}
+	if j.state != JobStatusWaitingApproval {
+		writeError(w, http.StatusBadRequest, "job is not waiting for approval", fmt.Sprintf("job %d status is %s", j.id, s.status(j)))
+		return
+	}
+	j.state = ""
+	j.started = s.now()
+	writeSuccess(w, "job approved", "")
+}
+
+func (s *Server) rejectJob(w http.ResponseWriter, r *http.Request) {
+	j, ok := s.jobFromPath(w, r)
+	if !ok {
+		return
+	}
+	if j.state != JobStatusWaitingApproval {
+		writeError(w, http.StatusBadRequest, "job is not waiting for approval", fmt.Sprintf("job %d status is %s", j.id, s.status(j)))
+		return
+	}
+	j.state = JobStatusRejected
+	j.end = s.now()
+	writeSuccess(w, "job rejected", "")
+}
+
+func (s *Server) abortJob(w http.ResponseWriter, r *http.Request) {
+	j, ok := s.jobFromPath(w, r)
+	if !ok {
+		return
+	}
+	if status := s.status(j); status != JobStatusQueued && status != JobStatusRunning {
+		writeError(w, http.StatusBadRequest, "job is not running", fmt.Sprintf("job %d status is %s", j.id, status))
+		return
+	}
+	j.state = JobStatusAborted
+	j.end = s.now()
+	writeSuccess(w, "job aborted", "")
+}
@@ -0,0 +1,88 @@
+package fakeserver
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// LDAP is the LDAP authentication configuration of Ansible Forms. The bind password is never returned.
+type LDAP struct {
+	Server            string `json:"server"`
+	Port              int64  `json:"port"`
+	IgnoreCerts       bool   `json:"ignore_certs"`
+	EnableTLS         bool   `json:"enable_tls"`
+	Cert              string `json:"cert"`
+	BindUserDN        string `json:"bind_user_dn"`
+	BindUserPassword  string `json:"bind_user_pw,omitempty"`
+	SearchBase        string `json:"search_base"`
+	UsernameAttribute string `json:"username_attribute"`
+	GroupsSearchBase  string `json:"groups_search_base"`
+	GroupsAttribute   string `json:"groups_attribute"`
+	Enable            bool   `json:"enable"`
+}
+
+// defaultLDAP returns the LDAP configuration of a new Ansible Forms installation, disabled.
+func defaultLDAP() LDAP {
+	return LDAP{Port: 389, UsernameAttribute: "sAMAccountName", GroupsAttribute: "memberOf"}
+}
+
+// LDAP returns the LDAP configuration, including the bind password.
+func (s *Server) LDAP() LDAP {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.ldap
+}
+
+// ReplaceLDAP replaces the LDAP configuration, as a change made outside of the provider.
+func (s *Server) ReplaceLDAP(ldap LDAP) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.ldap = ldap
+}
+
+func (s *Server) registerLDAP(mux *http.ServeMux) {
+	s.handle(mux, "GET ldap", s.getLDAP)
+	s.handle(mux, "PUT ldap", s.updateLDAP)
+	s.handle(mux, "POST ldap/check", s.checkLDAP)
+}
+
+func (s *Server) getLDAP(w http.ResponseWriter, _ *http.Request) {
+	ldap := s.ldap
+	ldap.BindUserPassword = ""
+	writeSuccess(w, "", ldap)
+}
+
+func (s *Server) updateLDAP(w http.ResponseWriter, r *http.Request) {
+	update := s.ldap
+	if !decodeBody(w, r, &update) {
+		return
+	}
+	if update.Port < 1 || update.Port > 65535 {
+		writeError(w, http.StatusBadRequest, "failed to update ldap", fmt.Sprintf("invalid port %d", update.Port))
+		return
+	}
+	if update.Enable && update.Server == "" {
+		writeError(w, http.StatusBadRequest, "failed to update ldap", "server is required when ldap is enabled")
+		return
+	}
+	// the bind password is only changed when it is provided
+	if update.BindUserPassword == "" {
+		update.BindUserPassword = s.ldap.BindUserPassword
+	}
+	s.ldap = update
+	writeSuccess(w, "ldap updated", "")
+}
+
+// checkLDAP tests the connection with the saved configuration. Servers in the .invalid domain are unreachable, and a
+// bind password is required.
+func (s *Server) checkLDAP(w http.ResponseWriter, _ *http.Request) {
+	switch {
+	case s.ldap.Server == "" || strings.HasSuffix(s.ldap.Server, ".invalid"):
+		writeError(w, http.StatusBadRequest, "ldap check failed", fmt.Sprintf("connect ECONNREFUSED %s:%d", s.ldap.Server, s.ldap.Port))
+	case s.ldap.BindUserPassword == "":
+		writeError(w, http.StatusBadRequest, "ldap check failed", "invalid credentials for "+s.ldap.BindUserDN)
+	default:
+		writeSuccess(w, "ldap connection ok", "")
+	}
+}
@@ -0,0 +1,204 @@
+package fakeserver
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Repository is a git repository cloned by Ansible Forms, for playbooks or forms.
+// Status and LastCommit are set by Ansible Forms when it clones or pulls the repository.
+type Repository struct {
+	ID              int64  `json:"id"`
+	Name            string `json:"name"`
+	URI             string `json:"uri"`
+	Branch          string `json:"branch"`
+	Credential      string `json:"credential"`
+	UseForForms     bool   `json:"use_for_forms"`
+	UseForPlaybooks bool   `json:"use_for_playbooks"`
+	Cron            string `json:"cron"`
+	Status          string `json:"status"`
+	LastCommit      string `json:"last_commit"`
+}
+
+// AddRepository adds a cloned repository, and returns its id.
+func (s *Server) AddRepository(repository Repository) int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	repository.ID = s.newID()
+	s.clone(&repository, "cloned")
+	s.repositories[repository.ID] = &repository
+	return repository.ID
+}
+
+// RepositoryByName returns a repository, and whether it exists.
+func (s *Server) RepositoryByName(name string) (Repository, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	repository, ok := s.repositoryByName(name)
+	if !ok {
+		return Repository{}, false
+	}
+	return *repository, true
+}
+
+// PushCommit sets the latest commit of the branch of uri, as a push to the remote repository. The repositories cloned
+// from it get the commit on their next pull.
+func (s *Server) PushCommit(uri string, branch string, commit string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.remoteCommits[uri+"#"+branch] = commit
+}
+
+func (s *Server) registerRepositories(mux *http.ServeMux) {
+	s.handle(mux, "GET repository/{$}", s.listRepositories)
+	s.handle(mux, "POST repository/{$}", s.createRepository)
+	s.handle(mux, "GET repository/{id}", s.getRepository)
+	s.handle(mux, "PUT repository/{id}", s.updateRepository)
+	s.handle(mux, "DELETE repository/{id}", s.deleteRepository)
+	s.handle(mux, "POST repository/{id}/pull", s.pullRepository)
+}
+
+// repositoryByName returns the repository with name, and whether it exists.
+func (s *Server) repositoryByName(name string) (*Repository, bool) {
+	for _, repository := range s.repositories {
+		if repository.Name == name {
+			return repository, true
+		}
+	}
+	return nil, false
+}
+
+// clone clones or pulls the branch of the repository, and sets its status and last commit. Hosts in the .invalid
+// domain cannot be resolved. The latest commit of a branch is derived from its uri, unless it was pushed.
+func (s *Server) clone(repository *Repository, status string) {
+	if parsed, err := url.Parse(repository.URI); err == nil && strings.HasSuffix(parsed.Hostname(), ".invalid") {
+		repository.Status = fmt.Sprintf("error: could not resolve host %s", parsed.Hostname())
+		return
+	}
+	commit, ok := s.remoteCommits[repository.URI+"#"+repository.Branch]
+	if !ok {
+		hash := sha1.Sum([]byte(repository.URI + "#" + repository.Branch))
+		commit = hex.EncodeToString(hash[:])
+	}
+	repository.Status = status
+	repository.LastCommit = commit
+}
+
+// checkRepository validates a repository, and reports a 400 error when it is not valid.
+func (s *Server) checkRepository(w http.ResponseWriter, repository *Repository, message string) bool {
+	if repository.Name == "" || repository.URI == "" {
+		writeError(w, http.StatusBadRequest, message, "name and uri are required")
+		return false
+	}
+	if repository.Credential != "" {
+		if _, exists := s.credentialByName(repository.Credential); !exists {
+			writeError(w, http.StatusBadRequest, message, fmt.Sprintf("credential '%s' not found", repository.Credential))
+			return false
+		}
+	}
+	if repository.Cron != "" && len(strings.Fields(repository.Cron)) != 5 {
+		writeError(w, http.StatusBadRequest, message, fmt.Sprintf("invalid cron expression '%s'", repository.Cron))
+		return false
+	}
+	return true
+}
+
+func (s *Server) listRepositories(w http.ResponseWriter, _ *http.Request) {
+	repositories := []Repository{}
+	for _, repository := range s.repositories {
+		repositories = append(repositories, *repository)
+	}
+	sort.Slice(repositories, func(i, j int) bool { return repositories[i].ID < repositories[j].ID })
+	writeSuccess(w, "", repositories)
+}
+
+// repositoryFromPath returns the repository identified by the id path value, and reports a 404 error when it does not
+// exist.
+func (s *Server) repositoryFromPath(w http.ResponseWriter, r *http.Request) (*Repository, bool) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return nil, false
+	}
+	repository, ok := s.repositories[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "repository not found", fmt.Sprintf("no repository found with id %d", id))
+		return nil, false
+	}
+	return repository, true
+}
+
+// createRepository adds a repository and clones it. A failed clone is reported in the status of the repository.
+func (s *Server) createRepository(w http.ResponseWriter, r *http.Request) {
+	var repository Repository
+	if !decodeBody(w, r, &repository) {
+		return
+	}
+	if !s.checkRepository(w, &repository, "failed to create repository") {
+		return
+	}
+	if _, exists := s.repositoryByName(repository.Name); exists {
+		writeError(w, http.StatusConflict, "failed to create repository", fmt.Sprintf("repository '%s' already exists", repository.Name))
+		return
+	}
+	repository.ID = s.newID()
+	s.clone(&repository, "cloned")
+	s.repositories[repository.ID] = &repository
+	writeSuccess(w, "repository created", map[string]any{"id": repository.ID})
+}
+
+func (s *Server) getRepository(w http.ResponseWriter, r *http.Request) {
+	repository, ok := s.repositoryFromPath(w, r)
+	if !ok {
+		return
+	}
+	writeSuccess(w, "", *repository)
+}
+
+// updateRepository updates a repository, which is cloned again when its uri or branch changed.
+func (s *Server) updateRepository(w http.ResponseWriter, r *http.Request) {
+	repository, ok := s.repositoryFromPath(w, r)
+	if !ok {
+		return
+	}
+	update := *repository
+	if !decodeBody(w, r, &update) {
+		return
+	}
+	if !s.checkRepository(w, &update, "failed to update repository") {
+		return
+	}
+	if update.Name != repository.Name {
+		writeError(w, http.StatusBadRequest, "failed to update repository", "a repository cannot be renamed")
+		return
+	}
+	// status and last commit are read-only
+	update.ID, update.Status, update.LastCommit = repository.ID, repository.Status, repository.LastCommit
+	if update.URI != repository.URI || update.Branch != repository.Branch {
+		s.clone(&update, "cloned")
+	}
+	*repository = update
+	writeSuccess(w, "repository updated", "")
+}
+
+func (s *Server) deleteRepository(w http.ResponseWriter, r *http.Request) {
+	repository, ok := s.repositoryFromPath(w, r)
+	if !ok {
+		return
+	}
+	delete(s.repositories, repository.ID)
+	writeSuccess(w, "repository deleted", "")
+}
+
+func (s *Server) pullRepository(w http.ResponseWriter, r *http.Request) {
+	repository, ok := s.repositoryFromPath(w, r)
+	if !ok {
+		return
+	}
+	s.clone(repository, "pulled")
+	writeSuccess(w, "repository pulled", map[string]any{"status": repository.Status, "last_commit": repository.LastCommit})
+}
@@ -0,0 +1,217 @@
+// Package fakeserver provides an in-process fake Ansible Forms server, to run unit and acceptance tests without a
+// real Ansible Forms instance.
+//
+// It implements the subset of the Ansible Forms REST API used by the provider, keeps its objects in memory, and
+// simulates job state transitions based on elapsed time.
+package fakeserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// APIRoot is the path of the Ansible Forms REST API.
+const APIRoot = "/api/v1/"
+
+// Default credentials accepted by auth/login.
+const (
+	DefaultUsername = "admin"
+	DefaultPassword = "AnsibleForms!123"
+)
+
+// Request is a request received by the server, as recorded for assertions.
+type Request struct {
+	Method string
+	// Path is relative to the API root, eg job/12
+	Path string
+}
+
+// Server is a fake Ansible Forms server, using TLS with a self signed certificate.
+// Fields can be changed before the first request, use the methods to change the objects afterwards.
+type Server struct {
+	*httptest.Server
+
+	// Username and Password are accepted by auth/login
+	Username string
+	Password string
+	// QueueTime and RunTime control how long a job stays queued and running, both default to 0 and jobs complete on
+	// the first read
+	QueueTime time.Duration
+	RunTime   time.Duration
+
+	mutex         sync.Mutex
+	token         string
+	requests      []Request
+	config        map[string]any
+	forms         []map[string]any
+	outcomes      map[string]string
+	backups       map[string]map[string]any
+	jobs          map[int64]*job
+	credentials   map[int64]*Credential
+	users         map[int64]*User
+	groups        map[int64]*Group
+	ldap          LDAP
+	azureAD       AzureAD
+	oidc          OIDC
+	settings      map[string]any
+	awx           AWX
+	jobTemplates  []JobTemplate
+	repositories  map[int64]*Repository
+	remoteCommits map[string]string
+	nextID        int64
+	now           func() time.Time
+}
+
+// New starts a fake Ansible Forms server with a demo form, and stops it when the test ends.
+func New(t testing.TB) *Server {
+	t.Helper()
+	s := &Server{
+		Username:      DefaultUsername,
+		Password:      DefaultPassword,
+		token:         "fake-token-" + strconv.FormatInt(time.Now().UnixNano(), 36),
+		config:        defaultConfig(),
+		outcomes:      map[string]string{},
+		backups:       map[string]map[string]any{},
+		jobs:          map[int64]*job{},
+		credentials:   map[int64]*Credential{},
+		users:         map[int64]*User{},
+		groups:        map[int64]*Group{},
+		ldap:          defaultLDAP(),
+		settings:      defaultSettings(),
+		repositories:  map[int64]*Repository{},
+		remoteCommits: map[string]string{},
+		now:           time.Now,
+	}
+	s.AddForm(Form{Name: "Demo Form", Description: "Demo form", Type: "ansible", Playbook: "demo.yaml", Categories: []string{"Demo"}, Roles: []string{"public"}})
+	s.AddUser(User{Username: s.Username, GroupID: s.AddGroup(Group{Name: "admins"})})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+APIRoot+"auth/login", s.login)
+	s.handle(mux, "GET config", s.getConfig)
+	s.handle(mux, "PUT config", s.putConfig)
+	s.handle(mux, "POST config/validate", s.validateConfig)
+	s.handle(mux, "POST config/backup", s.backupConfig)
+	s.registerJobs(mux)
+	s.registerCredentials(mux)
+	s.registerUsers(mux)
+	s.registerGroups(mux)
+	s.registerLDAP(mux)
+	s.registerSSO(mux)
+	s.registerSettings(mux)
+	s.registerAWX(mux)
+	s.registerRepositories(mux)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not found", fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+	})
+
+	s.Server = httptest.NewTLSServer(s.recordRequests(mux))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Hostname returns the host and port of the server, to be used as a connection profile hostname.
+func (s *Server) Hostname() string {
+	return strings.TrimPrefix(s.URL, "https://")
+}
+
+// Requests returns the requests received so far, excluding auth/login.
+func (s *Server) Requests() []Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// handle registers an authenticated handler for pattern, a method and a path relative to the API root.
+func (s *Server) handle(mux *http.ServeMux, pattern string, handler http.HandlerFunc) {
+	method, path, _ := strings.Cut(pattern, " ")
+	mux.HandleFunc(method+" "+APIRoot+path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+s.token {
+			writeJSON(w, http.StatusUnauthorized, map[string]any{"error": "Unauthorized"})
+			return
+		}
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		handler(w, r)
+	})
+}
+
+// recordRequests keeps track of the requests, for Requests.
+func (s *Server) recordRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, APIRoot)
+		if path != "auth/login" {
+			s.mutex.Lock()
+			s.requests = append(s.requests, Request{Method: r.Method, Path: path})
+			s.mutex.Unlock()
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// login returns a token for valid basic authentication credentials.
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	username, password, ok := r.BasicAuth()
+	if !ok || username != s.Username || password != s.Password {
+		writeJSON(w, http.StatusUnauthorized, map[string]any{"error": "Unauthorized"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"token": s.token, "refreshtoken": s.token + "-refresh"})
+}
+
+// newID returns a new object id, ids are unique across object types.
+func (s *Server) newID() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+// writeJSON writes document with statusCode.
+func writeJSON(w http.ResponseWriter, statusCode int, document any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(document)
+}
+
+// writeSuccess writes an Ansible Forms success envelope, with output as data.output.
+func writeSuccess(w http.ResponseWriter, message string, output any) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"status":  "success",
+		"message": message,
+		"data":    map[string]any{"output": output, "error": ""},
+	})
+}
+
+// writeError writes an Ansible Forms error envelope.
+func writeError(w http.ResponseWriter, statusCode int, message string, detail string) {
+	writeJSON(w, statusCode, map[string]any{
+		"status":  "error",
+		"message": message,
+		"data":    map[string]any{"output": "", "error": detail},
+	})
+}
+
+// decodeBody decodes the JSON request body into v, and reports a 400 error on failure.
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body", err.Error())
+		return false
+	}
+	return true
+}
+
+// pathID returns the id path value, and reports a 400 error when it is not a number.
+func pathID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid id", err.Error())
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,248 @@
+package fakeserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// do sends a request to the server, and returns the status code and the decoded response.
+func do(t *testing.T, s *Server, method string, path string, body any, authenticated bool) (int, map[string]any) {
+	t.Helper()
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	req, err := http.NewRequest(method, s.URL+APIRoot+path, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if authenticated {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var document map[string]any
+	if err = json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, document
+}
+
+func TestServer_login(t *testing.T) {
+	s := New(t)
+	tests := []struct {
+		name     string
+		username string
+		password string
+		want     int
+	}{
+		{name: "valid", username: DefaultUsername, password: DefaultPassword, want: http.StatusOK},
+		{name: "invalid", username: DefaultUsername, password: "wrong", want: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, s.URL+APIRoot+"auth/login", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.SetBasicAuth(tt.username, tt.password)
+			resp, err := s.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("login statusCode = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+	if statusCode, _ := do(t, s, http.MethodGet, "job/", nil, false); statusCode != http.StatusUnauthorized {
+		t.Errorf("GET job/ without token statusCode = %d, want %d", statusCode, http.StatusUnauthorized)
+	}
+}
+
+func TestServer_jobTransitions(t *testing.T) {
+	s := New(t)
+	s.QueueTime = time.Minute
+	s.RunTime = time.Minute
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	_, document := do(t, s, http.MethodPost, "job/", map[string]any{"formName": "Demo Form"}, true)
+	id := int64(document["data"].(map[string]any)["output"].(map[string]any)["id"].(float64))
+	for _, want := range []struct {
+		elapsed time.Duration
+		status  string
+	}{
+		{0, JobStatusQueued},
+		{90 * time.Second, JobStatusRunning},
+		{3 * time.Minute, JobStatusSuccess},
+	} {
+		now = now.Add(want.elapsed)
+		if status, _ := s.JobStatus(id); status != want.status {
+			t.Errorf("JobStatus() after %s = %s, want %s", want.elapsed, status, want.status)
+		}
+	}
+	if statusCode, _ := do(t, s, http.MethodPost, "job/"+strconv.FormatInt(id, 10)+"/abort", nil, true); statusCode != http.StatusBadRequest {
+		t.Errorf("abort a finished job statusCode = %d, want %d", statusCode, http.StatusBadRequest)
+	}
+}
+
+func TestServer_jobActions(t *testing.T) {
+	tests := []struct {
+		name    string
+		form    Form
+		runTime time.Duration
+		actions []string
+		want    string
+	}{
+		{name: "approve", form: Form{Name: "Approval", Approval: &Approval{Title: "Approve"}}, actions: []string{"approve"}, want: JobStatusSuccess},
+		{name: "reject", form: Form{Name: "Approval", Approval: &Approval{Title: "Approve"}}, actions: []string{"reject"}, want: JobStatusRejected},
+		{name: "abort", form: Form{Name: "Long"}, runTime: time.Hour, actions: []string{"abort"}, want: JobStatusAborted},
+		{name: "failed", form: Form{Name: "Failing", Outcome: JobStatusFailed}, want: JobStatusFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(t)
+			s.AddForm(tt.form)
+			s.RunTime = tt.runTime
+			statusCode, document := do(t, s, http.MethodPost, "job/", map[string]any{"formName": tt.form.Name}, true)
+			if statusCode != http.StatusOK {
+				t.Fatalf("POST job/ statusCode = %d, response %v", statusCode, document)
+			}
+			id := int64(document["data"].(map[string]any)["output"].(map[string]any)["id"].(float64))
+			for _, action := range tt.actions {
+				if statusCode, document = do(t, s, http.MethodPost, "job/"+strconv.FormatInt(id, 10)+"/"+action, nil, true); statusCode != http.StatusOK {
+					t.Fatalf("POST %s statusCode = %d, response %v", action, statusCode, document)
+				}
+			}
+			_, document = do(t, s, http.MethodGet, "job/"+strconv.FormatInt(id, 10), nil, true)
+			if got := document["data"].(map[string]any)["status"]; got != tt.want {
+				t.Errorf("GET job status = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_notFound(t *testing.T) {
+	s := New(t)
+	statusCode, document := do(t, s, http.MethodGet, "job/42", nil, true)
+	if statusCode != http.StatusNotFound || document["status"] != "error" || document["message"] != "job not found" {
+		t.Errorf("GET job/42 = %d %v, want a 404 error envelope", statusCode, document)
+	}
+	if statusCode, _ = do(t, s, http.MethodGet, "unknown", nil, true); statusCode != http.StatusNotFound {
+		t.Errorf("GET unknown statusCode = %d, want %d", statusCode, http.StatusNotFound)
+	}
+}
+
+func TestServer_putConfig(t *testing.T) {
+	s := New(t)
+	s.AddForm(Form{Name: "Failing", Outcome: JobStatusFailed})
+	_, document := do(t, s, http.MethodGet, "config", nil, true)
+	config := document["data"].(map[string]any)["output"].(map[string]any)
+	forms := config["forms"].([]any)
+	forms[0].(map[string]any)["tileColor"] = "blue"
+	config["forms"] = append(forms, map[string]any{"name": "New Form", "playbook": "new.yaml"})
+	if statusCode, document := do(t, s, http.MethodPut, "config", config, true); statusCode != http.StatusOK {
+		t.Fatalf("PUT config statusCode = %d, response %v", statusCode, document)
+	}
+	if form, ok := s.FormDefinition("Demo Form"); !ok || form["tileColor"] != "blue" {
+		t.Errorf("FormDefinition(Demo Form) = %v, want tileColor to be kept", form)
+	}
+	if form, ok := s.form("Failing"); !ok || form.Outcome != JobStatusFailed {
+		t.Errorf("form(Failing) = %v, want the outcome to be kept", form)
+	}
+	if _, ok := s.FormDefinition("New Form"); !ok {
+		t.Errorf("FormDefinition(New Form) not found")
+	}
+	if s.ConfigSection("categories") == nil {
+		t.Errorf("ConfigSection(categories) = nil, want the categories to be kept")
+	}
+
+	config["forms"] = []any{map[string]any{"name": "Twice"}, map[string]any{"name": "Twice"}}
+	if statusCode, _ := do(t, s, http.MethodPut, "config", config, true); statusCode != http.StatusBadRequest {
+		t.Errorf("PUT config with duplicate names statusCode = %d, want %d", statusCode, http.StatusBadRequest)
+	}
+	config["forms"] = []any{}
+	config["categories"] = []any{map[string]any{"name": "Default"}, map[string]any{"name": "Default"}}
+	if statusCode, _ := do(t, s, http.MethodPut, "config", config, true); statusCode != http.StatusBadRequest {
+		t.Errorf("PUT config with duplicate category names statusCode = %d, want %d", statusCode, http.StatusBadRequest)
+	}
+}
+
+func TestServer_groups(t *testing.T) {
+	s := New(t)
+	_, document := do(t, s, http.MethodPost, "group/", map[string]any{"name": "storage"}, true)
+	id := int64(document["data"].(map[string]any)["output"].(map[string]any)["id"].(float64))
+	if statusCode, _ := do(t, s, http.MethodPost, "user/", map[string]any{"username": "jdoe", "group_id": 999}, true); statusCode != http.StatusBadRequest {
+		t.Errorf("POST user/ with unknown group statusCode = %d, want %d", statusCode, http.StatusBadRequest)
+	}
+	if statusCode, _ := do(t, s, http.MethodPost, "user/", map[string]any{"username": "jdoe", "group_id": id}, true); statusCode != http.StatusOK {
+		t.Fatalf("POST user/ statusCode = %d, want %d", statusCode, http.StatusOK)
+	}
+	path := "group/" + strconv.FormatInt(id, 10)
+	if statusCode, _ := do(t, s, http.MethodDelete, path, nil, true); statusCode != http.StatusConflict {
+		t.Errorf("DELETE %s with users statusCode = %d, want %d", path, statusCode, http.StatusConflict)
+	}
+	user, _ := s.UserByName("jdoe")
+	do(t, s, http.MethodDelete, "user/"+strconv.FormatInt(user.ID, 10), nil, true)
+	if statusCode, _ := do(t, s, http.MethodDelete, path, nil, true); statusCode != http.StatusOK {
+		t.Errorf("DELETE %s statusCode = %d, want %d", path, statusCode, http.StatusOK)
+	}
+}
+
+func TestServer_ldap(t *testing.T) {
+	s := New(t)
+	if statusCode, _ := do(t, s, http.MethodPost, "ldap/check", nil, true); statusCode != http.StatusBadRequest {
+		t.Errorf("POST ldap/check without server statusCode = %d, want %d", statusCode, http.StatusBadRequest)
+	}
+	if statusCode, _ := do(t, s, http.MethodPut, "ldap", map[string]any{"server": "dc1.example.com", "bind_user_pw": "secret", "enable": true}, true); statusCode != http.StatusOK {
+		t.Fatalf("PUT ldap statusCode = %d, want %d", statusCode, http.StatusOK)
+	}
+	// the bind password is kept when it is not provided
+	do(t, s, http.MethodPut, "ldap", map[string]any{"search_base": "dc=example,dc=com"}, true)
+	if ldap := s.LDAP(); ldap.BindUserPassword != "secret" || ldap.Port != 389 {
+		t.Errorf("LDAP() = %+v, want bind password secret and port 389", ldap)
+	}
+	_, document := do(t, s, http.MethodGet, "ldap", nil, true)
+	if _, ok := document["data"].(map[string]any)["output"].(map[string]any)["bind_user_pw"]; ok {
+		t.Errorf("GET ldap returned the bind password")
+	}
+	if statusCode, _ := do(t, s, http.MethodPost, "ldap/check", nil, true); statusCode != http.StatusOK {
+		t.Errorf("POST ldap/check statusCode = %d, want %d", statusCode, http.StatusOK)
+	}
+}
+
+func TestServer_repositories(t *testing.T) {
+	s := New(t)
+	repository := map[string]any{"name": "playbooks", "uri": "https://git.example.com/playbooks.git", "branch": "main", "credential": "git_cred"}
+	if statusCode, _ := do(t, s, http.MethodPost, "repository/", repository, true); statusCode != http.StatusBadRequest {
+		t.Errorf("POST repository/ with unknown credential statusCode = %d, want %d", statusCode, http.StatusBadRequest)
+	}
+	s.AddCredential(Credential{Name: "git_cred", Host: "git.example.com", User: "forms"})
+	if statusCode, _ := do(t, s, http.MethodPost, "repository/", repository, true); statusCode != http.StatusOK {
+		t.Fatalf("POST repository/ statusCode = %d, want %d", statusCode, http.StatusOK)
+	}
+	cloned, _ := s.RepositoryByName("playbooks")
+	if cloned.Status != "cloned" || cloned.LastCommit == "" {
+		t.Errorf("repository status = %s, last commit = %s, want cloned and a commit", cloned.Status, cloned.LastCommit)
+	}
+	s.PushCommit("https://git.example.com/playbooks.git", "main", "0123456789abcdef0123456789abcdef01234567")
+	_, document := do(t, s, http.MethodPost, "repository/"+strconv.FormatInt(cloned.ID, 10)+"/pull", nil, true)
+	if output := document["data"].(map[string]any)["output"].(map[string]any); output["last_commit"] != "0123456789abcdef0123456789abcdef01234567" || output["status"] != "pulled" {
+		t.Errorf("POST repository/%d/pull output = %v, want the pushed commit", cloned.ID, output)
+	}
+}
@@ -0,0 +1,77 @@
+package fakeserver
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// defaultSettings returns the general settings of a new Ansible Forms installation.
+// log_level stands for the settings the provider does not manage.
+func defaultSettings() map[string]any {
+	return map[string]any{
+		"url":                         "",
+		"mail_server":                 "",
+		"mail_port":                   float64(25),
+		"mail_secure":                 false,
+		"mail_username":               "",
+		"mail_from":                   "",
+		"job_retention_days":          float64(30),
+		"forms_backup_retention_days": float64(30),
+		"ui_title":                    "Ansible Forms",
+		"ui_theme":                    "light",
+		"log_level":                   "info",
+	}
+}
+
+// Settings returns a copy of the general settings, including the mail password.
+func (s *Server) Settings() map[string]any {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	settings := map[string]any{}
+	for key, value := range s.settings {
+		settings[key] = value
+	}
+	return settings
+}
+
+// SetSetting changes a general setting, as a change made outside of the provider.
+func (s *Server) SetSetting(key string, value any) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.settings[key] = value
+}
+
+func (s *Server) registerSettings(mux *http.ServeMux) {
+	s.handle(mux, "GET settings", s.getSettings)
+	s.handle(mux, "PUT settings", s.updateSettings)
+}
+
+func (s *Server) getSettings(w http.ResponseWriter, _ *http.Request) {
+	settings := map[string]any{}
+	for key, value := range s.settings {
+		if key != "mail_password" {
+			settings[key] = value
+		}
+	}
+	writeSuccess(w, "", settings)
+}
+
+// updateSettings replaces the general settings, as Ansible Forms does: the keys that are not sent are lost, except the
+// mail password, which is only changed when it is provided.
+func (s *Server) updateSettings(w http.ResponseWriter, r *http.Request) {
+	var update map[string]any
+	if !decodeBody(w, r, &update) {
+		return
+	}
+	if port, ok := update["mail_port"].(float64); ok && (port < 1 || port > 65535) {
+		writeError(w, http.StatusBadRequest, "failed to update settings", fmt.Sprintf("invalid mail_port %v", port))
+		return
+	}
+	if password, _ := update["mail_password"].(string); password == "" {
+		if password, ok := s.settings["mail_password"]; ok {
+			update["mail_password"] = password
+		}
+	}
+	s.settings = update
+	writeSuccess(w, "settings updated", "")
+}
@@ -0,0 +1,104 @@
+package fakeserver
+
+import (
+	"net/http"
+)
+
+// AzureAD is the Azure AD single sign-on configuration of Ansible Forms. The client secret is never returned.
+type AzureAD struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"secret_id,omitempty"`
+	GroupFilter  string `json:"groupfilter"`
+	Enable       bool   `json:"enable"`
+}
+
+// OIDC is the generic OpenID Connect single sign-on configuration of Ansible Forms. The client secret is never
+// returned.
+type OIDC struct {
+	Issuer       string `json:"issuer"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret,omitempty"`
+	GroupFilter  string `json:"groupfilter"`
+	Enable       bool   `json:"enable"`
+}
+
+// AzureAD returns the Azure AD configuration, including the client secret.
+func (s *Server) AzureAD() AzureAD {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.azureAD
+}
+
+// ReplaceAzureAD replaces the Azure AD configuration, as a change made outside of the provider.
+func (s *Server) ReplaceAzureAD(azureAD AzureAD) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.azureAD = azureAD
+}
+
+// OIDC returns the OIDC configuration, including the client secret.
+func (s *Server) OIDC() OIDC {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.oidc
+}
+
+// ReplaceOIDC replaces the OIDC configuration, as a change made outside of the provider.
+func (s *Server) ReplaceOIDC(oidc OIDC) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.oidc = oidc
+}
+
+func (s *Server) registerSSO(mux *http.ServeMux) {
+	s.handle(mux, "GET azuread", s.getAzureAD)
+	s.handle(mux, "PUT azuread", s.updateAzureAD)
+	s.handle(mux, "GET oidc", s.getOIDC)
+	s.handle(mux, "PUT oidc", s.updateOIDC)
+}
+
+func (s *Server) getAzureAD(w http.ResponseWriter, _ *http.Request) {
+	azureAD := s.azureAD
+	azureAD.ClientSecret = ""
+	writeSuccess(w, "", azureAD)
+}
+
+func (s *Server) updateAzureAD(w http.ResponseWriter, r *http.Request) {
+	update := s.azureAD
+	if !decodeBody(w, r, &update) {
+		return
+	}
+	if update.Enable && update.ClientID == "" {
+		writeError(w, http.StatusBadRequest, "failed to update azuread", "client_id is required when azuread is enabled")
+		return
+	}
+	// the client secret is only changed when it is provided
+	if update.ClientSecret == "" {
+		update.ClientSecret = s.azureAD.ClientSecret
+	}
+	s.azureAD = update
+	writeSuccess(w, "azuread updated", "")
+}
+
+func (s *Server) getOIDC(w http.ResponseWriter, _ *http.Request) {
+	oidc := s.oidc
+	oidc.ClientSecret = ""
+	writeSuccess(w, "", oidc)
+}
+
+func (s *Server) updateOIDC(w http.ResponseWriter, r *http.Request) {
+	update := s.oidc
+	if !decodeBody(w, r, &update) {
+		return
+	}
+	if update.Enable && (update.ClientID == "" || update.Issuer == "") {
+		writeError(w, http.StatusBadRequest, "failed to update oidc", "issuer and client_id are required when oidc is enabled")
+		return
+	}
+	// the client secret is only changed when it is provided
+	if update.ClientSecret == "" {
+		update.ClientSecret = s.oidc.ClientSecret
+	}
+	s.oidc = update
+	writeSuccess(w, "oidc updated", "")
+}
@@ -0,0 +1,156 @@
+package fakeserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// User is a local Ansible Forms user. The password is never returned.
+type User struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Password string `json:"password,omitempty"`
+	GroupID  int64  `json:"group_id"`
+}
+
+// AddUser adds a user, and returns its id.
+func (s *Server) AddUser(user User) int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	user.ID = s.newID()
+	s.users[user.ID] = &user
+	return user.ID
+}
+
+// UserByName returns a user, including its password, and whether it exists.
+func (s *Server) UserByName(username string) (User, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	user, ok := s.userByName(username)
+	if !ok {
+		return User{}, false
+	}
+	return *user, true
+}
+
+// ReplaceUser replaces the user with the same id, as a change made outside of the provider.
+func (s *Server) ReplaceUser(user User) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.users[user.ID] = &user
+}
+
+func (s *Server) registerUsers(mux *http.ServeMux) {
+	s.handle(mux, "GET user/{$}", s.listUsers)
+	s.handle(mux, "POST user/{$}", s.createUser)
+	s.handle(mux, "GET user/{id}", s.getUser)
+	s.handle(mux, "PUT user/{id}", s.updateUser)
+	s.handle(mux, "DELETE user/{id}", s.deleteUser)
+}
+
+// userByName returns the user with username, and whether it exists.
+func (s *Server) userByName(username string) (*User, bool) {
+	for _, user := range s.users {
+		if user.Username == username {
+			return user, true
+		}
+	}
+	return nil, false
+}
+
+// userWithoutPassword returns a copy of user, as reported by Ansible Forms.
+func userWithoutPassword(user *User) User {
+	result := *user
+	result.Password = ""
+	return result
+}
+
+func (s *Server) listUsers(w http.ResponseWriter, _ *http.Request) {
+	users := []User{}
+	for _, user := range s.users {
+		users = append(users, userWithoutPassword(user))
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	writeSuccess(w, "", users)
+}
+
+// userFromPath returns the user identified by the id path value, and reports a 404 error when it does not exist.
+func (s *Server) userFromPath(w http.ResponseWriter, r *http.Request) (*User, bool) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return nil, false
+	}
+	user, ok := s.users[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "user not found", fmt.Sprintf("no user found with id %d", id))
+		return nil, false
+	}
+	return user, true
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	var user User
+	if !decodeBody(w, r, &user) {
+		return
+	}
+	if user.Username == "" {
+		writeError(w, http.StatusBadRequest, "failed to create user", "username is required")
+		return
+	}
+	if _, exists := s.userByName(user.Username); exists {
+		writeError(w, http.StatusConflict, "failed to create user", fmt.Sprintf("user '%s' already exists", user.Username))
+		return
+	}
+	if _, exists := s.groups[user.GroupID]; !exists {
+		writeError(w, http.StatusBadRequest, "failed to create user", fmt.Sprintf("no group found with id %d", user.GroupID))
+		return
+	}
+	user.ID = s.newID()
+	s.users[user.ID] = &user
+	writeSuccess(w, "user created", map[string]any{"id": user.ID})
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.userFromPath(w, r)
+	if !ok {
+		return
+	}
+	writeSuccess(w, "", userWithoutPassword(user))
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.userFromPath(w, r)
+	if !ok {
+		return
+	}
+	update := *user
+	if !decodeBody(w, r, &update) {
+		return
+	}
+	if other, exists := s.userByName(update.Username); exists && other.ID != user.ID {
+		writeError(w, http.StatusConflict, "failed to update user", fmt.Sprintf("user '%s' already exists", update.Username))
+		return
+	}
+	if _, exists := s.groups[update.GroupID]; !exists {
+		writeError(w, http.StatusBadRequest, "failed to update user", fmt.Sprintf("no group found with id %d", update.GroupID))
+		return
+	}
+	// the password is only changed when it is provided
+	if update.Password == "" {
+		update.Password = user.Password
+	}
+	update.ID = user.ID
+	*user = update
+	writeSuccess(w, "user updated", "")
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.userFromPath(w, r)
+	if !ok {
+		return
+	}
+	delete(s.users, user.ID)
+	writeSuccess(w, "user deleted", "")
+}
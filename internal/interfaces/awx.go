@@ -0,0 +1,93 @@
+package interfaces
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mitchellh/mapstructure"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// AWXResourceModel describes the body of an AWX configuration update request.
+// The token and the password are omitted when they are empty.
+type AWXResourceModel struct {
+	URI            string `mapstructure:"uri"`
+	Token          string `mapstructure:"token,omitempty"`
+	Username       string `mapstructure:"username"`
+	Password       string `mapstructure:"password,omitempty"`
+	UseCredentials bool   `mapstructure:"use_credentials"`
+	IgnoreCerts    bool   `mapstructure:"ignore_certs"`
+	CABundle       string `mapstructure:"ca_bundle"`
+}
+
+// AWXGetDataSourceModel describes the AWX configuration as returned by Ansible Forms, which never returns the token
+// and the password.
+type AWXGetDataSourceModel struct {
+	URI            string `mapstructure:"uri"`
+	Username       string `mapstructure:"username"`
+	UseCredentials bool   `mapstructure:"use_credentials"`
+	IgnoreCerts    bool   `mapstructure:"ignore_certs"`
+	CABundle       string `mapstructure:"ca_bundle"`
+}
+
+// JobTemplateGetDataSourceModel describes an AWX job template, as listed through Ansible Forms.
+type JobTemplateGetDataSourceModel struct {
+	ID          int64  `mapstructure:"id"`
+	Name        string `mapstructure:"name"`
+	Description string `mapstructure:"description"`
+}
+
+// GetAWX returns the AWX configuration.
+func GetAWX(errorHandler *utils.ErrorHandler, r restclient.Client) (*AWXGetDataSourceModel, error) {
+	statusCode, response, err := r.GetNilOrOneRecord(errorHandler.Ctx, "awx", nil, nil)
+	if err != nil {
+		return nil, errorHandler.MakeAndReportError("error reading awx configuration", fmt.Sprintf("error on GET awx: %s, statusCode %d", err, statusCode))
+	}
+	if response == nil {
+		return nil, errorHandler.MakeAndReportError("error reading awx configuration", fmt.Sprintf("no awx configuration in GET awx response, statusCode %d", statusCode))
+	}
+
+	var awx AWXGetDataSourceModel
+	if err = restclient.Decode(response, &awx); err != nil {
+		return nil, errorHandler.MakeAndReportError("failed to decode response from GET awx", fmt.Sprintf("error: %s, statusCode %d", err, statusCode))
+	}
+
+	return &awx, nil
+}
+
+// UpdateAWX saves the AWX configuration.
+func UpdateAWX(errorHandler *utils.ErrorHandler, r restclient.Client, data AWXResourceModel) error {
+	var body map[string]any
+	if err := mapstructure.Decode(data, &body); err != nil {
+		// do not include the body, the token and the password are sensitive
+		return errorHandler.MakeAndReportError("error encoding awx body", fmt.Sprintf("error on encoding PUT awx body: %s, uri: %s", err, data.URI))
+	}
+
+	statusCode, _, err := r.CallReplaceMethod(errorHandler.Ctx, "awx", nil, body)
+	if err != nil {
+		return errorHandler.MakeAndReportError("error updating awx configuration", fmt.Sprintf("error on PUT awx: %s, statusCode %d", err, statusCode))
+	}
+
+	return nil
+}
+
+// GetJobTemplates returns the AWX job templates visible through Ansible Forms, with the AWX configuration of Ansible
+// Forms.
+func GetJobTemplates(errorHandler *utils.ErrorHandler, r restclient.Client) ([]JobTemplateGetDataSourceModel, error) {
+	statusCode, response, err := r.GetZeroOrMoreRecords(errorHandler.Ctx, "awx/jobtemplates", nil, nil)
+	if err != nil {
+		return nil, errorHandler.MakeAndReportError("error reading awx job templates", fmt.Sprintf("error on GET awx/jobtemplates: %s, statusCode %d", err, statusCode))
+	}
+
+	templates := make([]JobTemplateGetDataSourceModel, len(response))
+	for index, record := range response {
+		if err = restclient.Decode(record, &templates[index]); err != nil {
+			return nil, errorHandler.MakeAndReportError("failed to decode response from GET awx/jobtemplates", fmt.Sprintf("error: %s, statusCode %d", err, statusCode))
+		}
+	}
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("read %d awx job templates", len(templates)))
+
+	return templates, nil
+}
@@ -0,0 +1,51 @@
+package interfaces
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/restclient/restclienttest"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+func TestGetJobTemplates(t *testing.T) {
+	// AWX ids may be reported as strings
+	records := []map[string]any{{"id": "12", "name": "Create Share", "description": "CIFS share"}, {"id": 14, "name": "Delete Share"}}
+	tests := []struct {
+		name     string
+		response restclienttest.MockResponse
+		want     []JobTemplateGetDataSourceModel
+		wantErr  bool
+	}{
+		{name: "templates", want: []JobTemplateGetDataSourceModel{{ID: 12, Name: "Create Share", Description: "CIFS share"}, {ID: 14, Name: "Delete Share"}},
+			response: restclienttest.MockResponse{ExpectedMethod: "GET", ExpectedURL: "awx/jobtemplates", StatusCode: 200,
+				Response: restclient.RestResponse{Status: "success", NumRecords: len(records), Records: records}}},
+		{name: "not_configured", wantErr: true,
+			response: restclienttest.MockResponse{ExpectedMethod: "GET", ExpectedURL: "awx/jobtemplates", StatusCode: 400, Err: &restclient.APIError{StatusCode: 400, Message: "awx is not configured"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			errorHandler := utils.NewErrorHandler(context.Background(), &diags)
+			client, err := restclienttest.NewMockedRestClient(t, []restclienttest.MockResponse{tt.response})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := GetJobTemplates(errorHandler, client)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetJobTemplates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("GetJobTemplates() = %#v, want %#v", got, tt.want)
+			}
+			for index := range got {
+				if got[index] != tt.want[index] {
+					t.Errorf("GetJobTemplates()[%d] = %#v, want %#v", index, got[index], tt.want[index])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package interfaces
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// AzureADResourceModel describes the body of an Azure AD configuration update request.
+// The client secret is omitted when it is empty.
+type AzureADResourceModel struct {
+	ClientID     string `mapstructure:"client_id"`
+	ClientSecret string `mapstructure:"secret_id,omitempty"`
+	GroupFilter  string `mapstructure:"groupfilter"`
+	Enable       bool   `mapstructure:"enable"`
+}
+
+// AzureADGetDataSourceModel describes the Azure AD configuration as returned by Ansible Forms, which never returns the
+// client secret.
+type AzureADGetDataSourceModel struct {
+	ClientID    string `mapstructure:"client_id"`
+	GroupFilter string `mapstructure:"groupfilter"`
+	Enable      bool   `mapstructure:"enable"`
+}
+
+// GetAzureAD returns the Azure AD configuration.
+func GetAzureAD(errorHandler *utils.ErrorHandler, r restclient.Client) (*AzureADGetDataSourceModel, error) {
+	statusCode, response, err := r.GetNilOrOneRecord(errorHandler.Ctx, "azuread", nil, nil)
+	if err != nil {
+		return nil, errorHandler.MakeAndReportError("error reading azuread configuration", fmt.Sprintf("error on GET azuread: %s, statusCode %d", err, statusCode))
+	}
+	if response == nil {
+		return nil, errorHandler.MakeAndReportError("error reading azuread configuration", fmt.Sprintf("no azuread configuration in GET azuread response, statusCode %d", statusCode))
+	}
+
+	var azureAD AzureADGetDataSourceModel
+	if err = restclient.Decode(response, &azureAD); err != nil {
+		return nil, errorHandler.MakeAndReportError("failed to decode response from GET azuread", fmt.Sprintf("error: %s, statusCode %d", err, statusCode))
+	}
+
+	return &azureAD, nil
+}
+
+// UpdateAzureAD saves the Azure AD configuration.
+func UpdateAzureAD(errorHandler *utils.ErrorHandler, r restclient.Client, data AzureADResourceModel) error {
+	var body map[string]any
+	if err := mapstructure.Decode(data, &body); err != nil {
+		// do not include the body, the client secret is sensitive
+		return errorHandler.MakeAndReportError("error encoding azuread body", fmt.Sprintf("error on encoding PUT azuread body: %s, client_id: %s", err, data.ClientID))
+	}
+
+	statusCode, _, err := r.CallReplaceMethod(errorHandler.Ctx, "azuread", nil, body)
+	if err != nil {
+		return errorHandler.MakeAndReportError("error updating azuread configuration", fmt.Sprintf("error on PUT azuread: %s, statusCode %d", err, statusCode))
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package interfaces
+
+import (
+	"fmt"
+	"slices"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// GetCategoryDefinition returns the top level category name of the forms configuration, with all its keys.
+// It returns nil without error when the category does not exist.
+func GetCategoryDefinition(errorHandler *utils.ErrorHandler, r restclient.Client, name string) (map[string]any, error) {
+	return getConfigItem(errorHandler, r, "categories", "category", name)
+}
+
+// CreateCategoryDefinition adds a top level category to the forms configuration, it is an error when a category with
+// the same name exists.
+func CreateCategoryDefinition(errorHandler *utils.ErrorHandler, r restclient.Client, category map[string]any) error {
+	return createConfigItem(errorHandler, r, "categories", "category", category)
+}
+
+// UpdateCategoryDefinition updates a top level category in the forms configuration, it is an error when the category
+// does not exist. Only the name, the icon and the nested items of category are managed, see mergeCategory.
+func UpdateCategoryDefinition(errorHandler *utils.ErrorHandler, r restclient.Client, category map[string]any) error {
+	return UpdateFormsConfig(errorHandler, r, func(config map[string]any) error {
+		items, err := configItems(config, "categories")
+		if err != nil {
+			return err
+		}
+		index := configItemIndex(items, category["name"])
+		if index == -1 {
+			return fmt.Errorf("category %s does not exist", category["name"])
+		}
+		items[index] = mergeCategory(items[index], category)
+		config["categories"] = toAnyList(items)
+		return nil
+	})
+}
+
+// mergeCategory returns category with the keys of existing that are not managed by the provider, so that they are not
+// lost on update. The name and the icon are managed at every level, and the list of items at the top level: nested
+// items are matched by name, and keep their other keys, including their own items.
+func mergeCategory(existing map[string]any, category map[string]any) map[string]any {
+	merged := mergeUnmanagedKeys(existing, category, "name", "icon", "items")
+	items, ok := category["items"].([]any)
+	if !ok {
+		return merged
+	}
+	existingItems, _ := configItems(existing, "items")
+	mergedItems := make([]any, len(items))
+	for index, element := range items {
+		mergedItems[index] = element
+		item, ok := element.(map[string]any)
+		if !ok {
+			continue
+		}
+		if existingIndex := configItemIndex(existingItems, item["name"]); existingIndex != -1 {
+			mergedItems[index] = mergeUnmanagedKeys(existingItems[existingIndex], item, "name", "icon")
+		}
+	}
+	merged["items"] = mergedItems
+
+	return merged
+}
+
+// mergeUnmanagedKeys returns a copy of item with the keys of existing that are not in managed.
+// A managed key missing from item is removed.
+func mergeUnmanagedKeys(existing map[string]any, item map[string]any, managed ...string) map[string]any {
+	merged := make(map[string]any, len(existing)+len(item))
+	for key, value := range existing {
+		if !slices.Contains(managed, key) {
+			merged[key] = value
+		}
+	}
+	for key, value := range item {
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// DeleteCategoryDefinition removes a top level category from the forms configuration, the forms are left unchanged.
+// Deleting a category that does not exist is not an error.
+func DeleteCategoryDefinition(errorHandler *utils.ErrorHandler, r restclient.Client, name string) error {
+	return deleteConfigItem(errorHandler, r, "categories", name)
+}
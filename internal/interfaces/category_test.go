@@ -0,0 +1,63 @@
+package interfaces
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/restclient/restclienttest"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+func TestUpdateCategoryDefinition(t *testing.T) {
+	// NetApp has nested items and a key unknown to the provider, edited in the Ansible Forms UI
+	existing := map[string]any{"name": "Storage", "icon": "database", "color": "blue", "items": []any{
+		map[string]any{"name": "NetApp", "icon": "server", "items": []any{map[string]any{"name": "ONTAP", "items": []any{map[string]any{"name": "S3"}}}}},
+		map[string]any{"name": "Pure"},
+	}}
+	config := restclienttest.MockResponse{ExpectedMethod: "GET", ExpectedURL: "config", StatusCode: 200, Response: restclient.RestResponse{Status: "success", NumRecords: 1,
+		Records: []map[string]any{{"forms": []any{}, "categories": []any{map[string]any{"name": "Default"}, existing}}}}}
+	tests := []struct {
+		name      string
+		category  map[string]any
+		want      map[string]any
+		responses []restclienttest.MockResponse
+		wantErr   bool
+	}{
+		{name: "unmanaged_keys_kept",
+			category: map[string]any{"name": "Storage", "icon": "hdd", "items": []any{map[string]any{"name": "NetApp"}, map[string]any{"name": "Dell", "icon": "server"}}},
+			// the icon of NetApp is removed, Pure is removed, Dell is added, the items of NetApp are kept at every level
+			want: map[string]any{"name": "Storage", "icon": "hdd", "color": "blue", "items": []any{
+				map[string]any{"name": "NetApp", "items": []any{map[string]any{"name": "ONTAP", "items": []any{map[string]any{"name": "S3"}}}}},
+				map[string]any{"name": "Dell", "icon": "server"},
+			}}},
+		{name: "items_removed",
+			category: map[string]any{"name": "Storage"},
+			want:     map[string]any{"name": "Storage", "color": "blue"}},
+		{name: "not_found", wantErr: true,
+			category: map[string]any{"name": "Compute"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responses := []restclienttest.MockResponse{config}
+			if !tt.wantErr {
+				// the configuration is read again before it is saved
+				responses = append(responses, config, restclienttest.MockResponse{ExpectedMethod: "PUT", ExpectedURL: "config", StatusCode: 200,
+					ExpectedBody: map[string]any{"forms": []any{}, "categories": []any{map[string]any{"name": "Default"}, tt.want}},
+					Response:     restclient.RestResponse{Status: "success"}})
+			}
+			var diags diag.Diagnostics
+			errorHandler := utils.NewErrorHandler(context.Background(), &diags)
+			client, err := restclienttest.NewMockedRestClient(t, responses)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = UpdateCategoryDefinition(errorHandler, client, tt.category)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdateCategoryDefinition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package interfaces
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mitchellh/mapstructure"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// CredentialResourceModel describes the body of a credential create or update request.
+// The password is omitted when it is empty.
+type CredentialResourceModel struct {
+	Name        string `mapstructure:"name"`
+	Host        string `mapstructure:"host"`
+	User        string `mapstructure:"user"`
+	Password    string `mapstructure:"password,omitempty"`
+	Description string `mapstructure:"description"`
+	Secure      bool   `mapstructure:"secure"`
+}
+
+// CredentialGetDataSourceModel describes a credential as returned by Ansible Forms, which never returns the password.
+type CredentialGetDataSourceModel struct {
+	ID          int64  `mapstructure:"id"`
+	Name        string `mapstructure:"name"`
+	Host        string `mapstructure:"host"`
+	User        string `mapstructure:"user"`
+	Description string `mapstructure:"description"`
+	Secure      bool   `mapstructure:"secure"`
+}
+
+// GetCredentials returns all the credentials.
+func GetCredentials(errorHandler *utils.ErrorHandler, r restclient.Client) ([]CredentialGetDataSourceModel, error) {
+	statusCode, response, err := r.GetZeroOrMoreRecords(errorHandler.Ctx, "credential/", nil, nil)
+	if err != nil {
+		return nil, errorHandler.MakeAndReportError("error reading credentials", fmt.Sprintf("error on GET credential/: %s, statusCode %d", err, statusCode))
+	}
+
+	credentials := make([]CredentialGetDataSourceModel, len(response))
+	for index, record := range response {
+		if err = restclient.Decode(record, &credentials[index]); err != nil {
+			return nil, errorHandler.MakeAndReportError("failed to decode response from GET credential/", fmt.Sprintf("error: %s, statusCode %d", err, statusCode))
+		}
+	}
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("read %d credentials", len(credentials)))
+
+	return credentials, nil
+}
+
+// GetCredentialByID gets a credential by id.
+// It returns nil without error when the credential does not exist.
+func GetCredentialByID(errorHandler *utils.ErrorHandler, r restclient.Client, id string) (*CredentialGetDataSourceModel, error) {
+	statusCode, response, err := r.GetNilOrOneRecord(errorHandler.Ctx, "credential/"+id, nil, nil)
+	if restclient.IsNotFound(err) {
+		tflog.Debug(errorHandler.Ctx, fmt.Sprintf("credential %s not found: %s", id, err))
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errorHandler.MakeAndReportError("error reading credential", fmt.Sprintf("error on GET credential/: %s, statusCode %d", err, statusCode))
+	}
+	if response == nil {
+		return nil, nil
+	}
+
+	var credential CredentialGetDataSourceModel
+	if err = restclient.Decode(response, &credential); err != nil {
+		return nil, errorHandler.MakeAndReportError("failed to decode response from GET credential", fmt.Sprintf("error: %s, statusCode %d", err, statusCode))
+	}
+
+	return &credential, nil
+}
+
+// GetCredentialByName gets a credential by name, names are unique in Ansible Forms.
+// It returns nil without error when the credential does not exist.
+func GetCredentialByName(errorHandler *utils.ErrorHandler, r restclient.Client, name string) (*CredentialGetDataSourceModel, error) {
+	credentials, err := GetCredentials(errorHandler, r)
+	if err != nil {
+		return nil, err
+	}
+	for index := range credentials {
+		if credentials[index].Name == name {
+			return &credentials[index], nil
+		}
+	}
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("credential %s not found", name))
+
+	return nil, nil
+}
+
+// CreateCredential creates a credential, and returns its id.
+func CreateCredential(errorHandler *utils.ErrorHandler, r restclient.Client, data CredentialResourceModel) (int64, error) {
+	var body map[string]any
+	if err := mapstructure.Decode(data, &body); err != nil {
+		// do not include the body, the password is sensitive
+		return 0, errorHandler.MakeAndReportError("error encoding credential body", fmt.Sprintf("error on encoding POST credential/ body: %s, credential: %s", err, data.Name))
+	}
+
+	statusCode, response, err := r.CallCreateMethod(errorHandler.Ctx, "credential/", nil, body)
+	if err != nil {
+		return 0, errorHandler.MakeAndReportError("error creating credential", fmt.Sprintf("error on POST credential/: %s, statusCode %d", err, statusCode))
+	}
+
+	var created struct {
+		ID int64 `mapstructure:"id"`
+	}
+	if err = response.DecodeOutput(&created); err != nil {
+		return 0, errorHandler.MakeAndReportError("failed to decode response from POST credential/", fmt.Sprintf("error: %s, statusCode %d", err, statusCode))
+	}
+	if created.ID == 0 {
+		return 0, errorHandler.MakeAndReportError("error creating credential", fmt.Sprintf("no credential id in POST credential/ response: %s, statusCode %d", response.Message, statusCode))
+	}
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("created credential %s with id %d", data.Name, created.ID))
+
+	return created.ID, nil
+}
+
+// UpdateCredential updates a credential by id.
+func UpdateCredential(errorHandler *utils.ErrorHandler, r restclient.Client, id string, data CredentialResourceModel) error {
+	var body map[string]any
+	if err := mapstructure.Decode(data, &body); err != nil {
+		// do not include the body, the password is sensitive
+		return errorHandler.MakeAndReportError("error encoding credential body", fmt.Sprintf("error on encoding PUT credential/ body: %s, credential: %s", err, data.Name))
+	}
+
+	statusCode, _, err := r.CallReplaceMethod(errorHandler.Ctx, "credential/"+id, nil, body)
+	if err != nil {
+		return errorHandler.MakeAndReportError("error updating credential", fmt.Sprintf("error on PUT credential/: %s, statusCode %d", err, statusCode))
+	}
+
+	return nil
+}
+
+// DeleteCredentialByID deletes a credential by id.
+// Deleting a credential that does not exist is not an error.
+func DeleteCredentialByID(errorHandler *utils.ErrorHandler, r restclient.Client, id string) error {
+	statusCode, _, err := r.CallDeleteMethod(errorHandler.Ctx, "credential/"+id, nil, nil)
+	if restclient.IsNotFound(err) {
+		tflog.Debug(errorHandler.Ctx, fmt.Sprintf("credential %s already deleted: %s", id, err))
+		return nil
+	}
+	if err != nil {
+		return errorHandler.MakeAndReportError("error deleting credential", fmt.Sprintf("error on DELETE credential/: %s, statusCode %d", err, statusCode))
+	}
+
+	return nil
+}
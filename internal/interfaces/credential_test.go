@@ -0,0 +1,78 @@
+package interfaces
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/restclient/restclienttest"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+func TestCreateCredential(t *testing.T) {
+	created := restclient.RestResponse{Status: "success", Output: map[string]any{"id": 7}}
+	tests := []struct {
+		name     string
+		data     CredentialResourceModel
+		response restclienttest.MockResponse
+		want     int64
+		wantErr  bool
+	}{
+		{name: "with_password", want: 7, data: CredentialResourceModel{Name: "ontap_cred", Host: "ontap1", User: "admin", Password: "secret"},
+			response: restclienttest.MockResponse{ExpectedMethod: "POST", ExpectedURL: "credential/", StatusCode: 200, Response: created,
+				ExpectedBody: map[string]any{"name": "ontap_cred", "host": "ontap1", "user": "admin", "password": "secret", "description": "", "secure": false}}},
+		{name: "without_password", want: 7, data: CredentialResourceModel{Name: "ontap_cred", Host: "ontap1", User: "admin", Secure: true},
+			response: restclienttest.MockResponse{ExpectedMethod: "POST", ExpectedURL: "credential/", StatusCode: 200, Response: created,
+				ExpectedBody: map[string]any{"name": "ontap_cred", "host": "ontap1", "user": "admin", "description": "", "secure": true}}},
+		{name: "already_exists", wantErr: true, data: CredentialResourceModel{Name: "ontap_cred"},
+			response: restclienttest.MockResponse{ExpectedMethod: "POST", ExpectedURL: "credential/", StatusCode: 409, Err: &restclient.APIError{StatusCode: 409, Message: "credential 'ontap_cred' already exists"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			errorHandler := utils.NewErrorHandler(context.Background(), &diags)
+			client, err := restclienttest.NewMockedRestClient(t, []restclienttest.MockResponse{tt.response})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := CreateCredential(errorHandler, client, tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateCredential() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CreateCredential() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCredentialByName(t *testing.T) {
+	records := []map[string]any{{"id": 1, "name": "awx_cred", "host": "awx"}, {"id": 2, "name": "ontap_cred", "host": "ontap1"}}
+	list := restclienttest.MockResponse{ExpectedMethod: "GET", ExpectedURL: "credential/", StatusCode: 200,
+		Response: restclient.RestResponse{Status: "success", NumRecords: len(records), Records: records}}
+	for _, tt := range []struct {
+		name   string
+		wantID int64
+	}{
+		{name: "ontap_cred", wantID: 2},
+		{name: "missing"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			errorHandler := utils.NewErrorHandler(context.Background(), &diags)
+			client, err := restclienttest.NewMockedRestClient(t, []restclienttest.MockResponse{list})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := GetCredentialByName(errorHandler, client, tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (got == nil && tt.wantID != 0) || (got != nil && got.ID != tt.wantID) {
+				t.Errorf("GetCredentialByName() = %#v, want id %d", got, tt.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package interfaces
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// FormGetDataSourceModel describes a form, as found in the forms configuration.
+type FormGetDataSourceModel struct {
+	Name        string   `mapstructure:"name"`
+	Description string   `mapstructure:"description"`
+	Type        string   `mapstructure:"type"`
+	Categories  []string `mapstructure:"categories"`
+	Roles       []string `mapstructure:"roles"`
+	Image       string   `mapstructure:"image"`
+	Help        string   `mapstructure:"help"`
+	// Playbook is the target of ansible forms, Template the AWX job template of awx forms
+	Playbook string             `mapstructure:"playbook"`
+	Template string             `mapstructure:"template"`
+	Approval *FormApprovalModel `mapstructure:"approval"`
+	Fields   []FormFieldModel   `mapstructure:"fields"`
+}
+
+// FormApprovalModel describes the approval of the jobs launched with a form.
+type FormApprovalModel struct {
+	Title   string   `mapstructure:"title"`
+	Message string   `mapstructure:"message"`
+	Roles   []string `mapstructure:"roles"`
+}
+
+// FormFieldModel describes a form field, Default and Values keep the JSON types of the configuration.
+type FormFieldModel struct {
+	Name         string                `mapstructure:"name"`
+	Type         string                `mapstructure:"type"`
+	Label        string                `mapstructure:"label"`
+	Required     bool                  `mapstructure:"required"`
+	Default      any                   `mapstructure:"default"`
+	Values       any                   `mapstructure:"values"`
+	Expression   string                `mapstructure:"expression"`
+	Dependencies []FormDependencyModel `mapstructure:"dependencies"`
+	Hide         bool                  `mapstructure:"hide"`
+	NoOutput     bool                  `mapstructure:"noOutput"`
+	Multiple     bool                  `mapstructure:"multiple"`
+	Regex        *FormFieldRegexModel  `mapstructure:"regex"`
+	// AsCredential is set for fields holding the name of a credential, passed to the job in credentials
+	AsCredential bool `mapstructure:"asCredential"`
+}
+
+// FormFieldRegexModel is a regular expression the value of a field must match.
+type FormFieldRegexModel struct {
+	Expression  string `mapstructure:"expression"`
+	Description string `mapstructure:"description"`
+}
+
+// FormDependencyModel shows a field only when the field Name has one of Values.
+type FormDependencyModel struct {
+	Name   string `mapstructure:"name"`
+	Values []any  `mapstructure:"values"`
+}
+
+// RequiredCredentials returns the names of the fields holding credentials.
+func (f FormGetDataSourceModel) RequiredCredentials() []string {
+	var names []string
+	for _, field := range f.Fields {
+		if field.AsCredential {
+			names = append(names, field.Name)
+		}
+	}
+	return names
+}
+
+// ValidateExtravars checks extravars against the form fields, and returns a description of each problem, sorted.
+// A nil value is not known yet, eg at plan time, and is only checked for presence.
+// The problems never include the values, as they may be sensitive.
+func (f FormGetDataSourceModel) ValidateExtravars(extravars map[string]*string) []string {
+	var problems []string
+	fields := make(map[string]FormFieldModel, len(f.Fields))
+	for _, field := range f.Fields {
+		fields[field.Name] = field
+	}
+	for key, value := range extravars {
+		field, ok := fields[key]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s is not a field of form %s", key, f.Name))
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		if allowed := field.allowedValues(); allowed != nil && !contains(allowed, *value) {
+			problems = append(problems, fmt.Sprintf("%s must be one of %s", key, strings.Join(allowed, ", ")))
+		}
+		if field.Regex != nil && field.Regex.Expression != "" {
+			// Ansible Forms uses JavaScript regular expressions, the ones Go cannot compile are not checked
+			if re, err := regexp.Compile(field.Regex.Expression); err == nil && !re.MatchString(*value) {
+				problems = append(problems, fmt.Sprintf("%s does not match %s %s", key, field.Regex.Expression, field.Regex.Description))
+			}
+		}
+	}
+	for _, field := range f.Fields {
+		if _, ok := extravars[field.Name]; !ok && field.isRequired(extravars) {
+			problems = append(problems, fmt.Sprintf("%s is required by form %s", field.Name, f.Name))
+		}
+	}
+	sort.Strings(problems)
+
+	return problems
+}
+
+// isRequired reports whether a value must be provided for the field, fields with a default value or computed by the
+// form are not, nor fields hidden by their dependencies.
+func (f FormFieldModel) isRequired(extravars map[string]*string) bool {
+	if !f.Required || f.Hide || f.Default != nil || f.Expression != "" || f.Type == "expression" {
+		return false
+	}
+	for _, dependency := range f.Dependencies {
+		value := extravars[dependency.Name]
+		if value == nil || !contains(scalarsToStrings(dependency.Values), *value) {
+			return false
+		}
+	}
+	return true
+}
+
+// allowedValues returns the values allowed for the field, or nil when any value is allowed.
+// Only static lists of scalar values are checked, values computed by an expression or holding objects are not.
+func (f FormFieldModel) allowedValues() []string {
+	values, ok := f.Values.([]any)
+	if !ok || len(values) == 0 || f.Multiple || f.Expression != "" {
+		return nil
+	}
+	return scalarsToStrings(values)
+}
+
+// scalarsToStrings returns the string representation of values, or nil when a value is not a scalar.
+func scalarsToStrings(values []any) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			result = append(result, v)
+		case bool, int, int64, float64:
+			result = append(result, fmt.Sprint(v))
+		default:
+			return nil
+		}
+	}
+	return result
+}
+
+// contains reports whether values contains value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// FormsFilter selects forms, empty fields match all forms.
+type FormsFilter struct {
+	Category string
+	Name     *regexp.Regexp
+}
+
+// matches reports whether form is selected by the filter.
+func (f FormsFilter) matches(form FormGetDataSourceModel) bool {
+	if f.Name != nil && !f.Name.MatchString(form.Name) {
+		return false
+	}
+	if f.Category == "" {
+		return true
+	}
+	for _, category := range form.Categories {
+		if category == f.Category {
+			return true
+		}
+	}
+	return false
+}
+
+// GetForms returns the forms visible to the user of the connection profile, in configuration order.
+func GetForms(errorHandler *utils.ErrorHandler, r restclient.Client, filter FormsFilter) ([]FormGetDataSourceModel, error) {
+	config, err := getFormsConfig(errorHandler, r)
+	if err != nil {
+		return nil, err
+	}
+	forms := make([]FormGetDataSourceModel, 0, len(config))
+	for _, form := range config {
+		if filter.matches(form) {
+			forms = append(forms, form)
+		}
+	}
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("read %d forms, %d selected", len(config), len(forms)))
+
+	return forms, nil
+}
+
+// GetFormByName gets a form by name.
+// It returns nil without error when the form does not exist, or is not visible to the user.
+func GetFormByName(errorHandler *utils.ErrorHandler, r restclient.Client, name string) (*FormGetDataSourceModel, error) {
+	config, err := getFormsConfig(errorHandler, r)
+	if err != nil {
+		return nil, err
+	}
+	for _, form := range config {
+		if form.Name == name {
+			tflog.Debug(errorHandler.Ctx, fmt.Sprintf("read form %s: type %s, %d fields", form.Name, form.Type, len(form.Fields)))
+			return &form, nil
+		}
+	}
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("form %s not found", name))
+
+	return nil, nil
+}
+
+// getFormsConfig returns the forms from the forms configuration.
+func getFormsConfig(errorHandler *utils.ErrorHandler, r restclient.Client) ([]FormGetDataSourceModel, error) {
+	statusCode, response, err := r.GetNilOrOneRecord(errorHandler.Ctx, "config", nil, nil)
+	if err != nil {
+		return nil, errorHandler.MakeAndReportError("error reading forms", fmt.Sprintf("error on GET config: %s, statusCode %d", err, statusCode))
+	}
+
+	var config struct {
+		Forms []FormGetDataSourceModel `mapstructure:"forms"`
+	}
+	if response != nil {
+		if err = restclient.Decode(response, &config); err != nil {
+			return nil, errorHandler.MakeAndReportError("failed to decode response from GET config", fmt.Sprintf("error: %s, statusCode %d", err, statusCode))
+		}
+	}
+
+	return config.Forms, nil
+}
@@ -0,0 +1,173 @@
+package interfaces
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/restclient/restclienttest"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+func TestGetForms(t *testing.T) {
+	config := restclient.RestResponse{Status: "success", NumRecords: 1, Records: []map[string]any{{"forms": []any{
+		map[string]any{"name": "Demo Form", "type": "ansible", "categories": []any{"Demo"}, "roles": []any{"public"}},
+		map[string]any{"name": "Create Share", "type": "awx", "categories": []any{"Storage", "Demo"}},
+		map[string]any{"name": "Delete Share", "type": "multistep", "categories": []any{"Storage"}, "image": "share.png", "help": "Deletes a share"},
+	}}}}
+	tests := []struct {
+		name      string
+		filter    FormsFilter
+		responses []restclienttest.MockResponse
+		want      []string
+		wantErr   bool
+	}{
+		{name: "all", want: []string{"Demo Form", "Create Share", "Delete Share"}, responses: []restclienttest.MockResponse{
+			{ExpectedMethod: "GET", ExpectedURL: "config", StatusCode: 200, Response: config},
+		}},
+		{name: "category", filter: FormsFilter{Category: "Storage"}, want: []string{"Create Share", "Delete Share"}, responses: []restclienttest.MockResponse{
+			{ExpectedMethod: "GET", ExpectedURL: "config", StatusCode: 200, Response: config},
+		}},
+		{name: "name_and_category", filter: FormsFilter{Category: "Demo", Name: regexp.MustCompile("Share$")}, want: []string{"Create Share"}, responses: []restclienttest.MockResponse{
+			{ExpectedMethod: "GET", ExpectedURL: "config", StatusCode: 200, Response: config},
+		}},
+		{name: "no_forms", want: []string{}, responses: []restclienttest.MockResponse{
+			{ExpectedMethod: "GET", ExpectedURL: "config", StatusCode: 200, Response: restclient.RestResponse{Status: "success"}},
+		}},
+		{name: "unauthorized", wantErr: true, responses: []restclienttest.MockResponse{
+			{ExpectedMethod: "GET", ExpectedURL: "config", StatusCode: 401, Err: &restclient.APIError{StatusCode: 401, Message: "Unauthorized"}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			errorHandler := utils.NewErrorHandler(context.Background(), &diags)
+			client, err := restclienttest.NewMockedRestClient(t, tt.responses)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := GetForms(errorHandler, client, tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetForms() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			names := make([]string, len(got))
+			for index, form := range got {
+				names[index] = form.Name
+			}
+			if len(names) != len(tt.want) {
+				t.Fatalf("GetForms() = %v, want %v", names, tt.want)
+			}
+			for index := range names {
+				if names[index] != tt.want[index] {
+					t.Errorf("GetForms() = %v, want %v", names, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetFormByName(t *testing.T) {
+	config := restclient.RestResponse{Status: "success", NumRecords: 1, Records: []map[string]any{{"forms": []any{
+		map[string]any{"name": "Demo Form", "type": "ansible"},
+		map[string]any{"name": "Create Share", "type": "awx", "template": "Create Share", "approval": map[string]any{"title": "Approve"}, "fields": []any{
+			map[string]any{"name": "size", "type": "number", "required": true, "default": 10},
+			map[string]any{"name": "protocol", "type": "enum", "values": []any{"cifs", "nfs"}, "dependencies": []any{map[string]any{"name": "size", "values": []any{10, 20}}}},
+			map[string]any{"name": "ontap_cred", "type": "expression", "expression": "'ontap'", "hide": true, "asCredential": true},
+		}},
+	}}}}
+	tests := []struct {
+		name      string
+		form      string
+		wantNil   bool
+		wantErr   bool
+		responses []restclienttest.MockResponse
+	}{
+		{name: "found", form: "Create Share", responses: []restclienttest.MockResponse{
+			{ExpectedMethod: "GET", ExpectedURL: "config", StatusCode: 200, Response: config},
+		}},
+		{name: "not_found", form: "Delete Share", wantNil: true, responses: []restclienttest.MockResponse{
+			{ExpectedMethod: "GET", ExpectedURL: "config", StatusCode: 200, Response: config},
+		}},
+		{name: "server_error", form: "Create Share", wantNil: true, wantErr: true, responses: []restclienttest.MockResponse{
+			{ExpectedMethod: "GET", ExpectedURL: "config", StatusCode: 500, Err: &restclient.APIError{StatusCode: 500}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			errorHandler := utils.NewErrorHandler(context.Background(), &diags)
+			client, err := restclienttest.NewMockedRestClient(t, tt.responses)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := GetFormByName(errorHandler, client, tt.form)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetFormByName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (got == nil) != tt.wantNil {
+				t.Fatalf("GetFormByName() got = %#v, wantNil %v", got, tt.wantNil)
+			}
+			if got == nil {
+				return
+			}
+			if got.Template != "Create Share" || got.Approval == nil || got.Approval.Title != "Approve" || len(got.Fields) != 3 {
+				t.Errorf("GetFormByName() got = %#v", got)
+			}
+			if field := got.Fields[1]; len(field.Dependencies) != 1 || field.Dependencies[0].Name != "size" || len(field.Dependencies[0].Values) != 2 {
+				t.Errorf("GetFormByName() dependencies = %#v", field.Dependencies)
+			}
+			if credentials := got.RequiredCredentials(); len(credentials) != 1 || credentials[0] != "ontap_cred" {
+				t.Errorf("RequiredCredentials() = %v, want [ontap_cred]", credentials)
+			}
+		})
+	}
+}
+
+func TestFormGetDataSourceModel_ValidateExtravars(t *testing.T) {
+	form := FormGetDataSourceModel{Name: "Create Share", Fields: []FormFieldModel{
+		{Name: "share_name", Type: "text", Required: true, Regex: &FormFieldRegexModel{Expression: "^[a-z_]+$", Description: "lowercase"}},
+		{Name: "protocol", Type: "enum", Values: []any{"cifs", "nfs"}, Default: "cifs"},
+		{Name: "size", Type: "number", Required: true, Dependencies: []FormDependencyModel{{Name: "protocol", Values: []any{"nfs"}}}},
+		{Name: "svm", Type: "enum", Values: []any{map[string]any{"name": "svm1"}}},
+		{Name: "volume", Type: "enum", Values: []any{"vol1"}, Expression: "fn.fnRestBasic(...)"},
+		{Name: "owner", Type: "expression", Required: true, Expression: "'admin'"},
+		{Name: "approver", Type: "text", Required: true, Hide: true},
+		{Name: "pattern", Type: "text", Regex: &FormFieldRegexModel{Expression: "(?<=a)b"}},
+	}}
+	value := func(v string) *string { return &v }
+	tests := []struct {
+		name      string
+		extravars map[string]*string
+		want      []string
+	}{
+		{name: "valid", extravars: map[string]*string{"share_name": value("share")}},
+		{name: "valid_dependency", extravars: map[string]*string{"share_name": value("share"), "protocol": value("nfs"), "size": value("10")}},
+		{name: "missing_required", extravars: map[string]*string{}, want: []string{"share_name is required by form Create Share"}},
+		{name: "missing_required_dependency", extravars: map[string]*string{"share_name": value("share"), "protocol": value("nfs")}, want: []string{"size is required by form Create Share"}},
+		{name: "unknown_dependency", extravars: map[string]*string{"share_name": value("share"), "protocol": nil}},
+		{name: "unknown_key", extravars: map[string]*string{"share_name": value("share"), "sise": value("10")}, want: []string{"sise is not a field of form Create Share"}},
+		{name: "not_allowed", extravars: map[string]*string{"share_name": value("share"), "protocol": value("smb")}, want: []string{"protocol must be one of cifs, nfs"}},
+		{name: "regex_mismatch", extravars: map[string]*string{"share_name": value("Share")}, want: []string{"share_name does not match ^[a-z_]+$ lowercase"}},
+		{name: "unknown_value", extravars: map[string]*string{"share_name": nil, "protocol": nil}},
+		{name: "not_checked", extravars: map[string]*string{"share_name": value("share"), "svm": value("svm2"), "volume": value("vol2"), "pattern": value("c")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := form.ValidateExtravars(tt.extravars)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ValidateExtravars() = %q, want %q", got, tt.want)
+			}
+			for index := range got {
+				if got[index] != tt.want[index] {
+					t.Errorf("ValidateExtravars() = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
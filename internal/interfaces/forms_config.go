@@ -0,0 +1,301 @@
+package interfaces
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// formsConfigAttempts is the number of times a forms configuration update is attempted when the configuration is
+// modified concurrently.
+var formsConfigAttempts = 3
+
+// formsConfigMutex serializes the forms configuration updates of the provider, as Terraform applies resources
+// concurrently. The other writers are detected by comparing the configuration before it is saved, see UpdateFormsConfig.
+var formsConfigMutex sync.Mutex
+
+// errFormsConfigConflict is returned when the forms configuration was modified between a read and a write.
+var errFormsConfigConflict = errors.New("the forms configuration was modified concurrently")
+
+// GetFormsConfig returns the forms configuration document, with all its sections.
+func GetFormsConfig(errorHandler *utils.ErrorHandler, r restclient.Client) (map[string]any, error) {
+	statusCode, response, err := r.GetNilOrOneRecord(errorHandler.Ctx, "config", nil, nil)
+	if err != nil {
+		return nil, errorHandler.MakeAndReportError("error reading forms configuration", fmt.Sprintf("error on GET config: %s, statusCode %d", err, statusCode))
+	}
+	if response == nil {
+		return nil, errorHandler.MakeAndReportError("error reading forms configuration", fmt.Sprintf("no forms configuration in GET config response, statusCode %d", statusCode))
+	}
+
+	return response, nil
+}
+
+// UpdateFormsConfig applies update to the forms configuration and saves it, using optimistic locking: the configuration
+// is read again just before it is saved and compared with FormsConfigHash, and when it changed in between, update is
+// applied again to the latest configuration, up to formsConfigAttempts times. update must only change what it owns.
+// Ansible Forms does not version the configuration, so a change saved by another client between the comparison and the
+// PUT is still overwritten.
+func UpdateFormsConfig(errorHandler *utils.ErrorHandler, r restclient.Client, update func(config map[string]any) error) error {
+	formsConfigMutex.Lock()
+	defer formsConfigMutex.Unlock()
+	for attempt := 1; attempt <= formsConfigAttempts; attempt++ {
+		config, err := GetFormsConfig(errorHandler, r)
+		if err != nil {
+			return err
+		}
+		version, err := FormsConfigHash(config)
+		if err != nil {
+			return errorHandler.MakeAndReportError("error hashing forms configuration", err.Error())
+		}
+		// update works on a copy, so that config is not altered when it is compared
+		updated, err := copyDocument(config)
+		if err != nil {
+			return errorHandler.MakeAndReportError("error copying forms configuration", err.Error())
+		}
+		if err = update(updated); err != nil {
+			return errorHandler.MakeAndReportError("error updating forms configuration", err.Error())
+		}
+
+		err = putFormsConfig(errorHandler, r, version, updated)
+		if errors.Is(err, errFormsConfigConflict) {
+			tflog.Debug(errorHandler.Ctx, fmt.Sprintf("forms configuration modified concurrently, attempt %d of %d", attempt, formsConfigAttempts))
+			continue
+		}
+		if err != nil {
+			return errorHandler.MakeAndReportError("error saving forms configuration", err.Error())
+		}
+		return nil
+	}
+
+	return errorHandler.MakeAndReportError("error saving forms configuration",
+		fmt.Sprintf("%s, giving up after %d attempts", errFormsConfigConflict, formsConfigAttempts))
+}
+
+// putFormsConfig saves config, unless the configuration on the server no longer matches version.
+func putFormsConfig(errorHandler *utils.ErrorHandler, r restclient.Client, version string, config map[string]any) error {
+	statusCode, current, err := r.GetNilOrOneRecord(errorHandler.Ctx, "config", nil, nil)
+	if err != nil {
+		return fmt.Errorf("error on GET config: %s, statusCode %d", err, statusCode)
+	}
+	currentVersion, err := FormsConfigHash(current)
+	if err != nil {
+		return err
+	}
+	if currentVersion != version {
+		return errFormsConfigConflict
+	}
+	statusCode, _, err = r.CallReplaceMethod(errorHandler.Ctx, "config", nil, config)
+	if err != nil {
+		return fmt.Errorf("error on PUT config: %s, statusCode %d", err, statusCode)
+	}
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("saved forms configuration, previous version %s", version))
+
+	return nil
+}
+
+// FormsConfigHash returns the hex encoded SHA-256 of the JSON representation of config, used as its version.
+func FormsConfigHash(config map[string]any) (string, error) {
+	// encoding/json sorts map keys, so the document is stable
+	document, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(document)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// GetFormDefinition returns the definition of the form name, with all its keys.
+// It returns nil without error when the form does not exist.
+func GetFormDefinition(errorHandler *utils.ErrorHandler, r restclient.Client, name string) (map[string]any, error) {
+	return getConfigItem(errorHandler, r, "forms", "form", name)
+}
+
+// CreateFormDefinition adds a form to the forms configuration, it is an error when a form with the same name exists.
+func CreateFormDefinition(errorHandler *utils.ErrorHandler, r restclient.Client, form map[string]any) error {
+	return createConfigItem(errorHandler, r, "forms", "form", form)
+}
+
+// UpdateFormDefinition replaces a form in the forms configuration, it is an error when the form does not exist.
+func UpdateFormDefinition(errorHandler *utils.ErrorHandler, r restclient.Client, form map[string]any) error {
+	return updateConfigItem(errorHandler, r, "forms", "form", form)
+}
+
+// DeleteFormDefinition removes a form from the forms configuration.
+// Deleting a form that does not exist is not an error.
+func DeleteFormDefinition(errorHandler *utils.ErrorHandler, r restclient.Client, name string) error {
+	return deleteConfigItem(errorHandler, r, "forms", name)
+}
+
+// getConfigItem returns the item name of a section of the forms configuration, eg a form, with all its keys.
+// It returns nil without error when the item does not exist.
+func getConfigItem(errorHandler *utils.ErrorHandler, r restclient.Client, section string, kind string, name string) (map[string]any, error) {
+	config, err := GetFormsConfig(errorHandler, r)
+	if err != nil {
+		return nil, err
+	}
+	items, err := configItems(config, section)
+	if err != nil {
+		return nil, errorHandler.MakeAndReportError("error reading forms configuration", err.Error())
+	}
+	if index := configItemIndex(items, name); index != -1 {
+		return items[index], nil
+	}
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("%s %s not found", kind, name))
+
+	return nil, nil
+}
+
+// createConfigItem adds an item to a section of the forms configuration, it is an error when an item with the same
+// name exists.
+func createConfigItem(errorHandler *utils.ErrorHandler, r restclient.Client, section string, kind string, item map[string]any) error {
+	return UpdateFormsConfig(errorHandler, r, func(config map[string]any) error {
+		items, err := configItems(config, section)
+		if err != nil {
+			return err
+		}
+		if configItemIndex(items, item["name"]) != -1 {
+			return fmt.Errorf("%s %s already exists, import it to manage it", kind, item["name"])
+		}
+		config[section] = append(toAnyList(items), item)
+		return nil
+	})
+}
+
+// updateConfigItem replaces an item in a section of the forms configuration, it is an error when the item does not
+// exist.
+func updateConfigItem(errorHandler *utils.ErrorHandler, r restclient.Client, section string, kind string, item map[string]any) error {
+	return UpdateFormsConfig(errorHandler, r, func(config map[string]any) error {
+		items, err := configItems(config, section)
+		if err != nil {
+			return err
+		}
+		index := configItemIndex(items, item["name"])
+		if index == -1 {
+			return fmt.Errorf("%s %s does not exist", kind, item["name"])
+		}
+		items[index] = item
+		config[section] = toAnyList(items)
+		return nil
+	})
+}
+
+// deleteConfigItem removes an item from a section of the forms configuration.
+// Deleting an item that does not exist is not an error.
+func deleteConfigItem(errorHandler *utils.ErrorHandler, r restclient.Client, section string, name string) error {
+	return UpdateFormsConfig(errorHandler, r, func(config map[string]any) error {
+		items, err := configItems(config, section)
+		if err != nil {
+			return err
+		}
+		if index := configItemIndex(items, name); index != -1 {
+			items = append(items[:index], items[index+1:]...)
+		}
+		config[section] = toAnyList(items)
+		return nil
+	})
+}
+
+// configItems returns the items of a section of the forms configuration, eg the forms.
+func configItems(config map[string]any, section string) ([]map[string]any, error) {
+	var items []map[string]any
+	switch list := config[section].(type) {
+	case nil:
+	case []any:
+		for _, element := range list {
+			item, ok := element.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("unexpected item %#v in %s of the forms configuration", element, section)
+			}
+			items = append(items, item)
+		}
+	case []map[string]any:
+		items = list
+	default:
+		return nil, fmt.Errorf("unexpected %s %#v in forms configuration", section, config[section])
+	}
+
+	return items, nil
+}
+
+// configItemIndex returns the index of the item name, or -1.
+func configItemIndex(items []map[string]any, name any) int {
+	for index, item := range items {
+		if item["name"] == name {
+			return index
+		}
+	}
+	return -1
+}
+
+// toAnyList returns documents as a list of any, as decoded from JSON.
+func toAnyList(documents []map[string]any) []any {
+	list := make([]any, len(documents))
+	for index, document := range documents {
+		list[index] = document
+	}
+	return list
+}
+
+// copyDocument returns a deep copy of document.
+func copyDocument(document map[string]any) (map[string]any, error) {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]any
+	err = json.Unmarshal(data, &result)
+
+	return result, err
+}
+
+// ValidateFormsConfig asks the server to check a forms configuration, without saving it.
+func ValidateFormsConfig(errorHandler *utils.ErrorHandler, r restclient.Client, config map[string]any) error {
+	statusCode, _, err := r.CallCreateMethod(errorHandler.Ctx, "config/validate", nil, config)
+	if err != nil {
+		return errorHandler.MakeAndReportError("invalid forms configuration", fmt.Sprintf("error on POST config/validate: %s, statusCode %d", err, statusCode))
+	}
+
+	return nil
+}
+
+// BackupFormsConfig triggers a server side backup of the forms configuration, and returns the backup name.
+func BackupFormsConfig(errorHandler *utils.ErrorHandler, r restclient.Client) (string, error) {
+	statusCode, response, err := r.CallCreateMethod(errorHandler.Ctx, "config/backup", nil, nil)
+	if err != nil {
+		return "", errorHandler.MakeAndReportError("error backing up forms configuration", fmt.Sprintf("error on POST config/backup: %s, statusCode %d", err, statusCode))
+	}
+	var backup struct {
+		Backup string `mapstructure:"backup"`
+	}
+	if err = response.DecodeOutput(&backup); err != nil {
+		return "", errorHandler.MakeAndReportError("failed to decode response from POST config/backup", fmt.Sprintf("error: %s, statusCode %d", err, statusCode))
+	}
+	if backup.Backup == "" {
+		return "", errorHandler.MakeAndReportError("error backing up forms configuration", fmt.Sprintf("no backup name in POST config/backup response: %s, statusCode %d", response.Message, statusCode))
+	}
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("backed up forms configuration to %s", backup.Backup))
+
+	return backup.Backup, nil
+}
+
+// ReplaceFormsConfig replaces the whole forms configuration with config.
+func ReplaceFormsConfig(errorHandler *utils.ErrorHandler, r restclient.Client, config map[string]any) error {
+	return UpdateFormsConfig(errorHandler, r, func(current map[string]any) error {
+		for key := range current {
+			delete(current, key)
+		}
+		for key, value := range config {
+			current[key] = value
+		}
+		return nil
+	})
+}
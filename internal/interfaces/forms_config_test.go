@@ -0,0 +1,121 @@
+package interfaces
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/restclient/restclienttest"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+func TestCreateFormDefinition(t *testing.T) {
+	// each config response is a new document, as decoded from JSON
+	config := func(forms ...string) restclienttest.MockResponse {
+		list := []any{}
+		for _, name := range forms {
+			list = append(list, map[string]any{"name": name})
+		}
+		record := map[string]any{"forms": list, "categories": []any{map[string]any{"name": "Default"}}}
+		return restclienttest.MockResponse{ExpectedMethod: "GET", ExpectedURL: "config", StatusCode: 200, Response: restclient.RestResponse{Status: "success", NumRecords: 1, Records: []map[string]any{record}}}
+	}
+	put := restclienttest.MockResponse{ExpectedMethod: "PUT", ExpectedURL: "config", StatusCode: 200,
+		ExpectedBody: map[string]any{
+			"forms":      []any{map[string]any{"name": "Demo Form"}, map[string]any{"name": "Other Form"}, map[string]any{"name": "Create Share"}},
+			"categories": []any{map[string]any{"name": "Default"}},
+		},
+		Response: restclient.RestResponse{Status: "success"}}
+	tests := []struct {
+		name      string
+		responses []restclienttest.MockResponse
+		wantErr   bool
+	}{
+		{name: "created", responses: []restclienttest.MockResponse{
+			config("Demo Form", "Other Form"), config("Demo Form", "Other Form"), put,
+		}},
+		{name: "concurrent_change", responses: []restclienttest.MockResponse{
+			// Other Form is added between the first read and the write, the update is applied again
+			config("Demo Form"), config("Demo Form", "Other Form"),
+			config("Demo Form", "Other Form"), config("Demo Form", "Other Form"), put,
+		}},
+		{name: "too_many_concurrent_changes", wantErr: true, responses: []restclienttest.MockResponse{
+			config("Demo Form"), config("Demo Form", "Other Form"),
+			config("Demo Form"), config("Demo Form", "Other Form"),
+			config("Demo Form"), config("Demo Form", "Other Form"),
+		}},
+		{name: "already_exists", wantErr: true, responses: []restclienttest.MockResponse{
+			config("Demo Form", "Create Share"),
+		}},
+		{name: "write_error", wantErr: true, responses: []restclienttest.MockResponse{
+			config("Demo Form", "Other Form"), config("Demo Form", "Other Form"),
+			{ExpectedMethod: "PUT", ExpectedURL: "config", StatusCode: 403, Err: &restclient.APIError{StatusCode: 403, Message: "Forbidden"}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			errorHandler := utils.NewErrorHandler(context.Background(), &diags)
+			client, err := restclienttest.NewMockedRestClient(t, tt.responses)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = CreateFormDefinition(errorHandler, client, map[string]any{"name": "Create Share"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateFormDefinition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("CreateFormDefinition() diagnostics = %v, wantErr %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormsConfigHash(t *testing.T) {
+	first, err := FormsConfigHash(map[string]any{"forms": []any{map[string]any{"name": "a", "type": "ansible"}}, "constants": map[string]any{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := FormsConfigHash(map[string]any{"constants": map[string]any{}, "forms": []any{map[string]any{"type": "ansible", "name": "a"}}})
+	third, _ := FormsConfigHash(map[string]any{"constants": map[string]any{}, "forms": []any{map[string]any{"type": "awx", "name": "a"}}})
+	if first != second {
+		t.Errorf("FormsConfigHash() depends on the order of the keys: %s, %s", first, second)
+	}
+	if first == third {
+		t.Errorf("FormsConfigHash() = %s for different configurations", first)
+	}
+}
+
+func TestBackupFormsConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		response restclienttest.MockResponse
+		want     string
+		wantErr  bool
+	}{
+		{name: "backed_up", want: "forms.yaml.20261019120000", response: restclienttest.MockResponse{ExpectedMethod: "POST", ExpectedURL: "config/backup", StatusCode: 200,
+			Response: restclient.RestResponse{Status: "success", Output: map[string]any{"backup": "forms.yaml.20261019120000"}}}},
+		{name: "no_backup_name", wantErr: true, response: restclienttest.MockResponse{ExpectedMethod: "POST", ExpectedURL: "config/backup", StatusCode: 200,
+			Response: restclient.RestResponse{Status: "success", Output: map[string]any{}}}},
+		{name: "error", wantErr: true, response: restclienttest.MockResponse{ExpectedMethod: "POST", ExpectedURL: "config/backup", StatusCode: 500,
+			Err: &restclient.APIError{StatusCode: 500, Message: "disk full"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			errorHandler := utils.NewErrorHandler(context.Background(), &diags)
+			client, err := restclienttest.NewMockedRestClient(t, []restclienttest.MockResponse{tt.response})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := BackupFormsConfig(errorHandler, client)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BackupFormsConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("BackupFormsConfig() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
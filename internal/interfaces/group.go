@@ -0,0 +1,136 @@
+package interfaces
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mitchellh/mapstructure"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// GroupResourceModel describes the body of a group create or update request.
+type GroupResourceModel struct {
+	Name string `mapstructure:"name"`
+}
+
+// GroupGetDataSourceModel describes a local group as returned by Ansible Forms.
+type GroupGetDataSourceModel struct {
+	ID   int64  `mapstructure:"id"`
+	Name string `mapstructure:"name"`
+}
+
+// GetGroups returns all the local groups.
+func GetGroups(errorHandler *utils.ErrorHandler, r restclient.Client) ([]GroupGetDataSourceModel, error) {
+	statusCode, response, err := r.GetZeroOrMoreRecords(errorHandler.Ctx, "group/", nil, nil)
+	if err != nil {
+		return nil, errorHandler.MakeAndReportError("error reading groups", fmt.Sprintf("error on GET group/: %s, statusCode %d", err, statusCode))
+	}
+
+	groups := make([]GroupGetDataSourceModel, len(response))
+	for index, record := range response {
+		if err = restclient.Decode(record, &groups[index]); err != nil {
+			return nil, errorHandler.MakeAndReportError("failed to decode response from GET group/", fmt.Sprintf("error: %s, statusCode %d", err, statusCode))
+		}
+	}
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("read %d groups", len(groups)))
+
+	return groups, nil
+}
+
+// GetGroupByID gets a group by id.
+// It returns nil without error when the group does not exist.
+func GetGroupByID(errorHandler *utils.ErrorHandler, r restclient.Client, id string) (*GroupGetDataSourceModel, error) {
+	statusCode, response, err := r.GetNilOrOneRecord(errorHandler.Ctx, "group/"+id, nil, nil)
+	if restclient.IsNotFound(err) {
+		tflog.Debug(errorHandler.Ctx, fmt.Sprintf("group %s not found: %s", id, err))
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errorHandler.MakeAndReportError("error reading group", fmt.Sprintf("error on GET group/: %s, statusCode %d", err, statusCode))
+	}
+	if response == nil {
+		return nil, nil
+	}
+
+	var group GroupGetDataSourceModel
+	if err = restclient.Decode(response, &group); err != nil {
+		return nil, errorHandler.MakeAndReportError("failed to decode response from GET group", fmt.Sprintf("error: %s, statusCode %d", err, statusCode))
+	}
+
+	return &group, nil
+}
+
+// GetGroupByName gets a local group by name, names are unique in Ansible Forms.
+// It returns nil without error when the group does not exist.
+func GetGroupByName(errorHandler *utils.ErrorHandler, r restclient.Client, name string) (*GroupGetDataSourceModel, error) {
+	groups, err := GetGroups(errorHandler, r)
+	if err != nil {
+		return nil, err
+	}
+	for index := range groups {
+		if groups[index].Name == name {
+			return &groups[index], nil
+		}
+	}
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("group %s not found", name))
+
+	return nil, nil
+}
+
+// CreateGroup creates a group, and returns its id.
+func CreateGroup(errorHandler *utils.ErrorHandler, r restclient.Client, data GroupResourceModel) (int64, error) {
+	var body map[string]any
+	if err := mapstructure.Decode(data, &body); err != nil {
+		return 0, errorHandler.MakeAndReportError("error encoding group body", fmt.Sprintf("error on encoding POST group/ body: %s, group: %s", err, data.Name))
+	}
+
+	statusCode, response, err := r.CallCreateMethod(errorHandler.Ctx, "group/", nil, body)
+	if err != nil {
+		return 0, errorHandler.MakeAndReportError("error creating group", fmt.Sprintf("error on POST group/: %s, statusCode %d", err, statusCode))
+	}
+
+	var created struct {
+		ID int64 `mapstructure:"id"`
+	}
+	if err = response.DecodeOutput(&created); err != nil {
+		return 0, errorHandler.MakeAndReportError("failed to decode response from POST group/", fmt.Sprintf("error: %s, statusCode %d", err, statusCode))
+	}
+	if created.ID == 0 {
+		return 0, errorHandler.MakeAndReportError("error creating group", fmt.Sprintf("no group id in POST group/ response: %s, statusCode %d", response.Message, statusCode))
+	}
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("created group %s with id %d", data.Name, created.ID))
+
+	return created.ID, nil
+}
+
+// UpdateGroup updates a group by id.
+func UpdateGroup(errorHandler *utils.ErrorHandler, r restclient.Client, id string, data GroupResourceModel) error {
+	var body map[string]any
+	if err := mapstructure.Decode(data, &body); err != nil {
+		return errorHandler.MakeAndReportError("error encoding group body", fmt.Sprintf("error on encoding PUT group/ body: %s, group: %s", err, data.Name))
+	}
+
+	statusCode, _, err := r.CallReplaceMethod(errorHandler.Ctx, "group/"+id, nil, body)
+	if err != nil {
+		return errorHandler.MakeAndReportError("error updating group", fmt.Sprintf("error on PUT group/: %s, statusCode %d", err, statusCode))
+	}
+
+	return nil
+}
+
+// DeleteGroupByID deletes a group by id, Ansible Forms refuses to delete a group that still has users.
+// Deleting a group that does not exist is not an error.
+func DeleteGroupByID(errorHandler *utils.ErrorHandler, r restclient.Client, id string) error {
+	statusCode, _, err := r.CallDeleteMethod(errorHandler.Ctx, "group/"+id, nil, nil)
+	if restclient.IsNotFound(err) {
+		tflog.Debug(errorHandler.Ctx, fmt.Sprintf("group %s already deleted: %s", id, err))
+		return nil
+	}
+	if err != nil {
+		return errorHandler.MakeAndReportError("error deleting group", fmt.Sprintf("error on DELETE group/: %s, statusCode %d", err, statusCode))
+	}
+
+	return nil
+}
@@ -52,67 +52,88 @@ type JobGetDataSourceModel struct {
 	Approval    string `mapstructure:"approval"`
 }
 
-// GetJobResponse describes GET job response.
-type GetJobResponse struct {
-	Status  string                `mapstructure:"status"`
-	Message string                `mapstructure:"message"`
-	Data    JobGetDataSourceModel `mapstructure:"data"`
-}
-
-// CreateJobResponse ...
-type CreateJobResponse struct {
-	Status  string `json:"status"`
-	Message string `json:"message"`
-	Data    struct {
-		Output struct {
-			ID int64 `json:"id"`
-		} `json:"output"`
-		Error string `json:"error"`
-	} `json:"data"`
-}
-
 // GetJobByID gets job info by id.
-func GetJobByID(errorHandler *utils.ErrorHandler, r restclient.RestClient, id string) (*JobGetDataSourceModel, error) {
-	statusCode, response, err := r.GetNilOrOneRecord("job/"+id, nil, nil)
+// It returns nil without error when the job does not exist.
+func GetJobByID(errorHandler *utils.ErrorHandler, r restclient.Client, id string) (*JobGetDataSourceModel, error) {
+	statusCode, response, err := r.GetNilOrOneRecord(errorHandler.Ctx, "job/"+id, nil, nil)
+	if restclient.IsNotFound(err) {
+		tflog.Debug(errorHandler.Ctx, fmt.Sprintf("job %s not found: %s", id, err))
+		return nil, nil
+	}
 	if err != nil {
 		return nil, errorHandler.MakeAndReportError("error reading job info", fmt.Sprintf("error on GET job/: %s, statusCode %d", err, statusCode))
 	}
-
-	var apiResp *GetJobResponse
-	if err = mapstructure.Decode(response, &apiResp); err != nil {
-		return nil, errorHandler.MakeAndReportError("failed to decode response from GET job", fmt.Sprintf("error: %s, statusCode %d, response %#v", err, statusCode, response))
+	if response == nil {
+		return nil, nil
 	}
-	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("read job info: %#v", apiResp.Data))
 
-	apiResp.Data.Status = apiResp.Status
+	var job JobGetDataSourceModel
+	if err = restclient.Decode(response, &job); err != nil {
+		return nil, errorHandler.MakeAndReportError("failed to decode response from GET job", fmt.Sprintf("error: %s, statusCode %d", err, statusCode))
+	}
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("read job info: id %d, form %s, status %s", job.ID, job.Form, job.Status))
 
-	return &apiResp.Data, nil
+	return &job, nil
 }
 
 // CreateJob creates a job.
-func CreateJob(errorHandler *utils.ErrorHandler, r restclient.RestClient, data JobResourceModel) (*GetJobResponse, error) {
+// Ansible Forms only returns the job id, the status is the status of the request.
+func CreateJob(errorHandler *utils.ErrorHandler, r restclient.Client, data JobResourceModel) (*JobGetDataSourceModel, error) {
 	var body map[string]interface{}
 	if err := mapstructure.Decode(data, &body); err != nil {
-		return nil, errorHandler.MakeAndReportError("error encoding job body", fmt.Sprintf("error on encoding POST job/ body: %s, body: %#v", err, data))
+		// do not include the body, extravars and credentials may be sensitive
+		return nil, errorHandler.MakeAndReportError("error encoding job body", fmt.Sprintf("error on encoding POST job/ body: %s, form: %s", err, data.Form))
 	}
 
-	statusCode, response, err := r.CallCreateMethod("job/", nil, body) // Ansible Forms API does not allow querying.
+	statusCode, response, err := r.CallCreateMethod(errorHandler.Ctx, "job/", nil, body) // Ansible Forms API does not allow querying.
 	if err != nil {
 		return nil, errorHandler.MakeAndReportError("error creating job", fmt.Sprintf("error on POST job/: %s, statusCode %d", err, statusCode))
 	}
 
-	var resp *CreateJobResponse
-	if err = mapstructure.Decode(response.Records[0], &resp); err != nil {
-		return nil, errorHandler.MakeAndReportError("failed to decode response from POST job/", fmt.Sprintf("error: %s, statusCode %d, response %#v", err, statusCode, response))
+	var created struct {
+		ID int64 `mapstructure:"id"`
+	}
+	if err = response.DecodeOutput(&created); err != nil {
+		return nil, errorHandler.MakeAndReportError("failed to decode response from POST job/", fmt.Sprintf("error: %s, statusCode %d", err, statusCode))
+	}
+	if created.ID == 0 {
+		return nil, errorHandler.MakeAndReportError("error creating job", fmt.Sprintf("no job id in POST job/ response: %s, statusCode %d", response.Message, statusCode))
 	}
-	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("Create svm source - udata: %#v", resp))
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("created job %d, status %s, message %s", created.ID, response.Status, response.Message))
 
-	return &GetJobResponse{Data: JobGetDataSourceModel{ID: resp.Data.Output.ID, Status: resp.Status}}, nil
+	return &JobGetDataSourceModel{ID: created.ID, Status: response.Status}, nil
+}
+
+// WaitForJob waits for a job to finish, and returns the last job info.
+// The job info is also returned when the job does not succeed, so that it can be saved in the state.
+func WaitForJob(errorHandler *utils.ErrorHandler, r restclient.Client, id string) (*JobGetDataSourceModel, error) {
+	statusCode, response, err := r.Wait(errorHandler.Ctx, id)
+	var job *JobGetDataSourceModel
+	if response.Output != nil {
+		job = &JobGetDataSourceModel{}
+		if decodeErr := response.DecodeOutput(job); decodeErr != nil {
+			return nil, errorHandler.MakeAndReportError("failed to decode response from GET job", fmt.Sprintf("error: %s, statusCode %d", decodeErr, statusCode))
+		}
+	}
+	if err != nil {
+		return job, errorHandler.MakeAndReportError("error waiting for job", fmt.Sprintf("error waiting for job %s: %s, statusCode %d", id, err, statusCode))
+	}
+	if job == nil {
+		return nil, errorHandler.MakeAndReportError("error waiting for job", fmt.Sprintf("no job info for job %s, statusCode %d", id, statusCode))
+	}
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("job %s finished, status %s", id, job.Status))
+
+	return job, nil
 }
 
 // DeleteJobByID deletes a job by ID.
-func DeleteJobByID(errorHandler *utils.ErrorHandler, r restclient.RestClient, id string) error {
-	statusCode, _, err := r.CallDeleteMethod("job/"+id, nil, nil)
+// Deleting a job that does not exist is not an error.
+func DeleteJobByID(errorHandler *utils.ErrorHandler, r restclient.Client, id string) error {
+	statusCode, _, err := r.CallDeleteMethod(errorHandler.Ctx, "job/"+id, nil, nil)
+	if restclient.IsNotFound(err) {
+		tflog.Debug(errorHandler.Ctx, fmt.Sprintf("job %s already deleted: %s", id, err))
+		return nil
+	}
 	if err != nil {
 		return errorHandler.MakeAndReportError("error deleting job info", fmt.Sprintf("error on DELETE job/: %s, statusCode %d", err, statusCode))
 	}
@@ -0,0 +1,99 @@
+package interfaces
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mitchellh/mapstructure"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// LDAPResourceModel describes the body of an LDAP configuration update request.
+// The bind password is omitted when it is empty.
+type LDAPResourceModel struct {
+	Server            string `mapstructure:"server"`
+	Port              int64  `mapstructure:"port"`
+	IgnoreCerts       bool   `mapstructure:"ignore_certs"`
+	EnableTLS         bool   `mapstructure:"enable_tls"`
+	Cert              string `mapstructure:"cert"`
+	BindUserDN        string `mapstructure:"bind_user_dn"`
+	BindUserPassword  string `mapstructure:"bind_user_pw,omitempty"`
+	SearchBase        string `mapstructure:"search_base"`
+	UsernameAttribute string `mapstructure:"username_attribute"`
+	GroupsSearchBase  string `mapstructure:"groups_search_base"`
+	GroupsAttribute   string `mapstructure:"groups_attribute"`
+	Enable            bool   `mapstructure:"enable"`
+}
+
+// LDAPGetDataSourceModel describes the LDAP configuration as returned by Ansible Forms, which never returns the bind
+// password.
+type LDAPGetDataSourceModel struct {
+	Server            string `mapstructure:"server"`
+	Port              int64  `mapstructure:"port"`
+	IgnoreCerts       bool   `mapstructure:"ignore_certs"`
+	EnableTLS         bool   `mapstructure:"enable_tls"`
+	Cert              string `mapstructure:"cert"`
+	BindUserDN        string `mapstructure:"bind_user_dn"`
+	SearchBase        string `mapstructure:"search_base"`
+	UsernameAttribute string `mapstructure:"username_attribute"`
+	GroupsSearchBase  string `mapstructure:"groups_search_base"`
+	GroupsAttribute   string `mapstructure:"groups_attribute"`
+	Enable            bool   `mapstructure:"enable"`
+}
+
+// GetLDAP returns the LDAP configuration.
+func GetLDAP(errorHandler *utils.ErrorHandler, r restclient.Client) (*LDAPGetDataSourceModel, error) {
+	statusCode, response, err := r.GetNilOrOneRecord(errorHandler.Ctx, "ldap", nil, nil)
+	if err != nil {
+		return nil, errorHandler.MakeAndReportError("error reading ldap configuration", fmt.Sprintf("error on GET ldap: %s, statusCode %d", err, statusCode))
+	}
+	if response == nil {
+		return nil, errorHandler.MakeAndReportError("error reading ldap configuration", fmt.Sprintf("no ldap configuration in GET ldap response, statusCode %d", statusCode))
+	}
+
+	var ldap LDAPGetDataSourceModel
+	if err = restclient.Decode(response, &ldap); err != nil {
+		return nil, errorHandler.MakeAndReportError("failed to decode response from GET ldap", fmt.Sprintf("error: %s, statusCode %d", err, statusCode))
+	}
+
+	return &ldap, nil
+}
+
+// UpdateLDAP saves the LDAP configuration.
+func UpdateLDAP(errorHandler *utils.ErrorHandler, r restclient.Client, data LDAPResourceModel) error {
+	var body map[string]any
+	if err := mapstructure.Decode(data, &body); err != nil {
+		// do not include the body, the bind password is sensitive
+		return errorHandler.MakeAndReportError("error encoding ldap body", fmt.Sprintf("error on encoding PUT ldap body: %s, server: %s", err, data.Server))
+	}
+
+	statusCode, _, err := r.CallReplaceMethod(errorHandler.Ctx, "ldap", nil, body)
+	if err != nil {
+		return errorHandler.MakeAndReportError("error updating ldap configuration", fmt.Sprintf("error on PUT ldap: %s, statusCode %d", err, statusCode))
+	}
+
+	return nil
+}
+
+// CheckLDAP tests the connection to the LDAP server with the saved configuration.
+// A failed connection is not an error: it returns the reason reported by Ansible Forms, and an empty string on success.
+func CheckLDAP(errorHandler *utils.ErrorHandler, r restclient.Client) (string, error) {
+	statusCode, _, err := r.CallCreateMethod(errorHandler.Ctx, "ldap/check", nil, nil)
+	var apiError *restclient.APIError
+	if errors.As(err, &apiError) && apiError.StatusCode == http.StatusBadRequest {
+		tflog.Debug(errorHandler.Ctx, fmt.Sprintf("ldap check failed: %s", err))
+		if apiError.Detail != "" {
+			return apiError.Detail, nil
+		}
+		return apiError.Message, nil
+	}
+	if err != nil {
+		return "", errorHandler.MakeAndReportError("error checking ldap connection", fmt.Sprintf("error on POST ldap/check: %s, statusCode %d", err, statusCode))
+	}
+
+	return "", nil
+}
@@ -0,0 +1,48 @@
+package interfaces
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/restclient/restclienttest"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+func TestCheckLDAP(t *testing.T) {
+	tests := []struct {
+		name     string
+		response restclienttest.MockResponse
+		want     string
+		wantErr  bool
+	}{
+		{name: "success", response: restclienttest.MockResponse{ExpectedMethod: "POST", ExpectedURL: "ldap/check", StatusCode: 200, Response: restclient.RestResponse{Status: "success"}}},
+		{name: "connection_failed", want: "connect ECONNREFUSED dc1.example.com:389",
+			response: restclienttest.MockResponse{ExpectedMethod: "POST", ExpectedURL: "ldap/check", StatusCode: 400,
+				Err: &restclient.APIError{StatusCode: 400, Message: "ldap check failed", Detail: "connect ECONNREFUSED dc1.example.com:389"}}},
+		{name: "forbidden", wantErr: true,
+			response: restclienttest.MockResponse{ExpectedMethod: "POST", ExpectedURL: "ldap/check", StatusCode: 403, Err: &restclient.APIError{StatusCode: 403, Message: "forbidden"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			errorHandler := utils.NewErrorHandler(context.Background(), &diags)
+			client, err := restclienttest.NewMockedRestClient(t, []restclienttest.MockResponse{tt.response})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := CheckLDAP(errorHandler, client)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckLDAP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CheckLDAP() = %q, want %q", got, tt.want)
+			}
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("CheckLDAP() diagnostics = %v, wantErr %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
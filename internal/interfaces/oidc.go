@@ -0,0 +1,63 @@
+package interfaces
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// OIDCResourceModel describes the body of an OIDC configuration update request.
+// The client secret is omitted when it is empty.
+type OIDCResourceModel struct {
+	Issuer       string `mapstructure:"issuer"`
+	ClientID     string `mapstructure:"client_id"`
+	ClientSecret string `mapstructure:"client_secret,omitempty"`
+	GroupFilter  string `mapstructure:"groupfilter"`
+	Enable       bool   `mapstructure:"enable"`
+}
+
+// OIDCGetDataSourceModel describes the OIDC configuration as returned by Ansible Forms, which never returns the client
+// secret.
+type OIDCGetDataSourceModel struct {
+	Issuer      string `mapstructure:"issuer"`
+	ClientID    string `mapstructure:"client_id"`
+	GroupFilter string `mapstructure:"groupfilter"`
+	Enable      bool   `mapstructure:"enable"`
+}
+
+// GetOIDC returns the OIDC configuration.
+func GetOIDC(errorHandler *utils.ErrorHandler, r restclient.Client) (*OIDCGetDataSourceModel, error) {
+	statusCode, response, err := r.GetNilOrOneRecord(errorHandler.Ctx, "oidc", nil, nil)
+	if err != nil {
+		return nil, errorHandler.MakeAndReportError("error reading oidc configuration", fmt.Sprintf("error on GET oidc: %s, statusCode %d", err, statusCode))
+	}
+	if response == nil {
+		return nil, errorHandler.MakeAndReportError("error reading oidc configuration", fmt.Sprintf("no oidc configuration in GET oidc response, statusCode %d", statusCode))
+	}
+
+	var oidc OIDCGetDataSourceModel
+	if err = restclient.Decode(response, &oidc); err != nil {
+		return nil, errorHandler.MakeAndReportError("failed to decode response from GET oidc", fmt.Sprintf("error: %s, statusCode %d", err, statusCode))
+	}
+
+	return &oidc, nil
+}
+
+// UpdateOIDC saves the OIDC configuration.
+func UpdateOIDC(errorHandler *utils.ErrorHandler, r restclient.Client, data OIDCResourceModel) error {
+	var body map[string]any
+	if err := mapstructure.Decode(data, &body); err != nil {
+		// do not include the body, the client secret is sensitive
+		return errorHandler.MakeAndReportError("error encoding oidc body", fmt.Sprintf("error on encoding PUT oidc body: %s, issuer: %s", err, data.Issuer))
+	}
+
+	statusCode, _, err := r.CallReplaceMethod(errorHandler.Ctx, "oidc", nil, body)
+	if err != nil {
+		return errorHandler.MakeAndReportError("error updating oidc configuration", fmt.Sprintf("error on PUT oidc: %s, statusCode %d", err, statusCode))
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package interfaces
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/restclient/restclienttest"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+func TestUpdateOIDC(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     OIDCResourceModel
+		response restclienttest.MockResponse
+		wantErr  bool
+	}{
+		{name: "without_secret", data: OIDCResourceModel{Issuer: "https://login.example.com", ClientID: "forms", Enable: true},
+			response: restclienttest.MockResponse{ExpectedMethod: "PUT", ExpectedURL: "oidc", StatusCode: 200, Response: restclient.RestResponse{Status: "success"},
+				ExpectedBody: map[string]any{"issuer": "https://login.example.com", "client_id": "forms", "groupfilter": "", "enable": true}}},
+		{name: "with_secret", data: OIDCResourceModel{Issuer: "https://login.example.com", ClientID: "forms", ClientSecret: "secret", GroupFilter: "^forms_"},
+			response: restclienttest.MockResponse{ExpectedMethod: "PUT", ExpectedURL: "oidc", StatusCode: 200, Response: restclient.RestResponse{Status: "success"},
+				ExpectedBody: map[string]any{"issuer": "https://login.example.com", "client_id": "forms", "client_secret": "secret", "groupfilter": "^forms_", "enable": false}}},
+		{name: "missing_issuer", wantErr: true, data: OIDCResourceModel{ClientID: "forms", Enable: true},
+			response: restclienttest.MockResponse{ExpectedMethod: "PUT", ExpectedURL: "oidc", StatusCode: 400, Err: &restclient.APIError{StatusCode: 400, Message: "issuer and client_id are required when oidc is enabled"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			errorHandler := utils.NewErrorHandler(context.Background(), &diags)
+			client, err := restclienttest.NewMockedRestClient(t, []restclienttest.MockResponse{tt.response})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = UpdateOIDC(errorHandler, client, tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdateOIDC() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("UpdateOIDC() diagnostics = %v, wantErr %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
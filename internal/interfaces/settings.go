@@ -0,0 +1,108 @@
+package interfaces
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// SettingsResourceModel describes the general settings to change. Nil fields are not managed, and keep their current
+// value, as does the mail password when it is nil.
+type SettingsResourceModel struct {
+	URL                      *string
+	MailServer               *string
+	MailPort                 *int64
+	MailSecure               *bool
+	MailUsername             *string
+	MailPassword             *string
+	MailFrom                 *string
+	JobRetentionDays         *int64
+	FormsBackupRetentionDays *int64
+	UITitle                  *string
+	UITheme                  *string
+}
+
+// SettingsGetDataSourceModel describes the general settings as returned by Ansible Forms, which never returns the mail
+// password. The other settings are ignored.
+type SettingsGetDataSourceModel struct {
+	URL                      string `mapstructure:"url"`
+	MailServer               string `mapstructure:"mail_server"`
+	MailPort                 int64  `mapstructure:"mail_port"`
+	MailSecure               bool   `mapstructure:"mail_secure"`
+	MailUsername             string `mapstructure:"mail_username"`
+	MailFrom                 string `mapstructure:"mail_from"`
+	JobRetentionDays         int64  `mapstructure:"job_retention_days"`
+	FormsBackupRetentionDays int64  `mapstructure:"forms_backup_retention_days"`
+	UITitle                  string `mapstructure:"ui_title"`
+	UITheme                  string `mapstructure:"ui_theme"`
+}
+
+// getSettingsDocument returns all the general settings, including the ones the provider does not manage.
+func getSettingsDocument(errorHandler *utils.ErrorHandler, r restclient.Client) (map[string]any, error) {
+	statusCode, response, err := r.GetNilOrOneRecord(errorHandler.Ctx, "settings", nil, nil)
+	if err != nil {
+		return nil, errorHandler.MakeAndReportError("error reading settings", fmt.Sprintf("error on GET settings: %s, statusCode %d", err, statusCode))
+	}
+	if response == nil {
+		return nil, errorHandler.MakeAndReportError("error reading settings", fmt.Sprintf("no settings in GET settings response, statusCode %d", statusCode))
+	}
+
+	return response, nil
+}
+
+// GetSettings returns the general settings.
+func GetSettings(errorHandler *utils.ErrorHandler, r restclient.Client) (*SettingsGetDataSourceModel, error) {
+	document, err := getSettingsDocument(errorHandler, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings SettingsGetDataSourceModel
+	if err = restclient.Decode(document, &settings); err != nil {
+		return nil, errorHandler.MakeAndReportError("failed to decode response from GET settings", fmt.Sprintf("error: %s", err))
+	}
+
+	return &settings, nil
+}
+
+// setIfNotNil sets key in document when value is not nil.
+func setIfNotNil[T any](document map[string]any, key string, value *T) {
+	if value != nil {
+		document[key] = *value
+	}
+}
+
+// UpdateSettings changes the settings of data that are not nil. Ansible Forms replaces all the settings on update, so
+// the current settings are read first and sent back unchanged, except the managed ones.
+func UpdateSettings(errorHandler *utils.ErrorHandler, r restclient.Client, data SettingsResourceModel) error {
+	current, err := getSettingsDocument(errorHandler, r)
+	if err != nil {
+		return err
+	}
+	body := make(map[string]any, len(current))
+	for key, value := range current {
+		body[key] = value
+	}
+	setIfNotNil(body, "url", data.URL)
+	setIfNotNil(body, "mail_server", data.MailServer)
+	setIfNotNil(body, "mail_port", data.MailPort)
+	setIfNotNil(body, "mail_secure", data.MailSecure)
+	setIfNotNil(body, "mail_username", data.MailUsername)
+	setIfNotNil(body, "mail_password", data.MailPassword)
+	setIfNotNil(body, "mail_from", data.MailFrom)
+	setIfNotNil(body, "job_retention_days", data.JobRetentionDays)
+	setIfNotNil(body, "forms_backup_retention_days", data.FormsBackupRetentionDays)
+	setIfNotNil(body, "ui_title", data.UITitle)
+	setIfNotNil(body, "ui_theme", data.UITheme)
+
+	statusCode, _, err := r.CallReplaceMethod(errorHandler.Ctx, "settings", nil, body)
+	if err != nil {
+		return errorHandler.MakeAndReportError("error updating settings", fmt.Sprintf("error on PUT settings: %s, statusCode %d", err, statusCode))
+	}
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("updated %d settings", len(body)))
+
+	return nil
+}
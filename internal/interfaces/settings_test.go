@@ -0,0 +1,53 @@
+package interfaces
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/restclient/restclienttest"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+func TestUpdateSettings(t *testing.T) {
+	current := map[string]any{"url": "https://old.example.com", "mail_port": float64(25), "log_level": "info"}
+	getSettings := restclienttest.MockResponse{ExpectedMethod: "GET", ExpectedURL: "settings", StatusCode: 200,
+		Response: restclient.RestResponse{Status: "success", NumRecords: 1, Records: []map[string]any{current}}}
+	url := "https://forms.example.com"
+	port := int64(587)
+	password := "secret"
+	tests := []struct {
+		name      string
+		data      SettingsResourceModel
+		responses []restclienttest.MockResponse
+		wantErr   bool
+	}{
+		{name: "unmanaged_kept", data: SettingsResourceModel{URL: &url},
+			responses: []restclienttest.MockResponse{getSettings, {ExpectedMethod: "PUT", ExpectedURL: "settings", StatusCode: 200, Response: restclient.RestResponse{Status: "success"},
+				ExpectedBody: map[string]any{"url": url, "mail_port": float64(25), "log_level": "info"}}}},
+		{name: "with_password", data: SettingsResourceModel{MailPort: &port, MailPassword: &password},
+			responses: []restclienttest.MockResponse{getSettings, {ExpectedMethod: "PUT", ExpectedURL: "settings", StatusCode: 200, Response: restclient.RestResponse{Status: "success"},
+				ExpectedBody: map[string]any{"url": "https://old.example.com", "mail_port": port, "mail_password": password, "log_level": "info"}}}},
+		{name: "get_error", wantErr: true, data: SettingsResourceModel{URL: &url},
+			responses: []restclienttest.MockResponse{{ExpectedMethod: "GET", ExpectedURL: "settings", StatusCode: 403, Err: &restclient.APIError{StatusCode: 403, Message: "forbidden"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			errorHandler := utils.NewErrorHandler(context.Background(), &diags)
+			client, err := restclienttest.NewMockedRestClient(t, tt.responses)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = UpdateSettings(errorHandler, client, tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdateSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("UpdateSettings() diagnostics = %v, wantErr %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package interfaces
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mitchellh/mapstructure"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// UserResourceModel describes the body of a user create or update request.
+// The password is omitted when it is empty.
+type UserResourceModel struct {
+	Username string `mapstructure:"username"`
+	Email    string `mapstructure:"email"`
+	Password string `mapstructure:"password,omitempty"`
+	GroupID  int64  `mapstructure:"group_id"`
+}
+
+// UserGetDataSourceModel describes a local user as returned by Ansible Forms, which never returns the password.
+type UserGetDataSourceModel struct {
+	ID       int64  `mapstructure:"id"`
+	Username string `mapstructure:"username"`
+	Email    string `mapstructure:"email"`
+	GroupID  int64  `mapstructure:"group_id"`
+}
+
+// GetUsers returns all the local users.
+func GetUsers(errorHandler *utils.ErrorHandler, r restclient.Client) ([]UserGetDataSourceModel, error) {
+	statusCode, response, err := r.GetZeroOrMoreRecords(errorHandler.Ctx, "user/", nil, nil)
+	if err != nil {
+		return nil, errorHandler.MakeAndReportError("error reading users", fmt.Sprintf("error on GET user/: %s, statusCode %d", err, statusCode))
+	}
+
+	users := make([]UserGetDataSourceModel, len(response))
+	for index, record := range response {
+		if err = restclient.Decode(record, &users[index]); err != nil {
+			return nil, errorHandler.MakeAndReportError("failed to decode response from GET user/", fmt.Sprintf("error: %s, statusCode %d", err, statusCode))
+		}
+	}
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("read %d users", len(users)))
+
+	return users, nil
+}
+
+// GetUserByID gets a user by id.
+// It returns nil without error when the user does not exist.
+func GetUserByID(errorHandler *utils.ErrorHandler, r restclient.Client, id string) (*UserGetDataSourceModel, error) {
+	statusCode, response, err := r.GetNilOrOneRecord(errorHandler.Ctx, "user/"+id, nil, nil)
+	if restclient.IsNotFound(err) {
+		tflog.Debug(errorHandler.Ctx, fmt.Sprintf("user %s not found: %s", id, err))
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errorHandler.MakeAndReportError("error reading user", fmt.Sprintf("error on GET user/: %s, statusCode %d", err, statusCode))
+	}
+	if response == nil {
+		return nil, nil
+	}
+
+	var user UserGetDataSourceModel
+	if err = restclient.Decode(response, &user); err != nil {
+		return nil, errorHandler.MakeAndReportError("failed to decode response from GET user", fmt.Sprintf("error: %s, statusCode %d", err, statusCode))
+	}
+
+	return &user, nil
+}
+
+// GetUserByName gets a local user by username, usernames are unique in Ansible Forms.
+// It returns nil without error when the user does not exist.
+func GetUserByName(errorHandler *utils.ErrorHandler, r restclient.Client, name string) (*UserGetDataSourceModel, error) {
+	users, err := GetUsers(errorHandler, r)
+	if err != nil {
+		return nil, err
+	}
+	for index := range users {
+		if users[index].Username == name {
+			return &users[index], nil
+		}
+	}
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("user %s not found", name))
+
+	return nil, nil
+}
+
+// CreateUser creates a user, and returns its id.
+func CreateUser(errorHandler *utils.ErrorHandler, r restclient.Client, data UserResourceModel) (int64, error) {
+	var body map[string]any
+	if err := mapstructure.Decode(data, &body); err != nil {
+		// do not include the body, the password is sensitive
+		return 0, errorHandler.MakeAndReportError("error encoding user body", fmt.Sprintf("error on encoding POST user/ body: %s, user: %s", err, data.Username))
+	}
+
+	statusCode, response, err := r.CallCreateMethod(errorHandler.Ctx, "user/", nil, body)
+	if err != nil {
+		return 0, errorHandler.MakeAndReportError("error creating user", fmt.Sprintf("error on POST user/: %s, statusCode %d", err, statusCode))
+	}
+
+	var created struct {
+		ID int64 `mapstructure:"id"`
+	}
+	if err = response.DecodeOutput(&created); err != nil {
+		return 0, errorHandler.MakeAndReportError("failed to decode response from POST user/", fmt.Sprintf("error: %s, statusCode %d", err, statusCode))
+	}
+	if created.ID == 0 {
+		return 0, errorHandler.MakeAndReportError("error creating user", fmt.Sprintf("no user id in POST user/ response: %s, statusCode %d", response.Message, statusCode))
+	}
+	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("created user %s with id %d", data.Username, created.ID))
+
+	return created.ID, nil
+}
+
+// UpdateUser updates a user by id.
+func UpdateUser(errorHandler *utils.ErrorHandler, r restclient.Client, id string, data UserResourceModel) error {
+	var body map[string]any
+	if err := mapstructure.Decode(data, &body); err != nil {
+		// do not include the body, the password is sensitive
+		return errorHandler.MakeAndReportError("error encoding user body", fmt.Sprintf("error on encoding PUT user/ body: %s, user: %s", err, data.Username))
+	}
+
+	statusCode, _, err := r.CallReplaceMethod(errorHandler.Ctx, "user/"+id, nil, body)
+	if err != nil {
+		return errorHandler.MakeAndReportError("error updating user", fmt.Sprintf("error on PUT user/: %s, statusCode %d", err, statusCode))
+	}
+
+	return nil
+}
+
+// DeleteUserByID deletes a user by id.
+// Deleting a user that does not exist is not an error.
+func DeleteUserByID(errorHandler *utils.ErrorHandler, r restclient.Client, id string) error {
+	statusCode, _, err := r.CallDeleteMethod(errorHandler.Ctx, "user/"+id, nil, nil)
+	if restclient.IsNotFound(err) {
+		tflog.Debug(errorHandler.Ctx, fmt.Sprintf("user %s already deleted: %s", id, err))
+		return nil
+	}
+	if err != nil {
+		return errorHandler.MakeAndReportError("error deleting user", fmt.Sprintf("error on DELETE user/: %s, statusCode %d", err, statusCode))
+	}
+
+	return nil
+}
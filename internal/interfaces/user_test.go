@@ -0,0 +1,47 @@
+package interfaces
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/restclient/restclienttest"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+func TestUpdateUser(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     UserResourceModel
+		response restclienttest.MockResponse
+		wantErr  bool
+	}{
+		{name: "without_password", data: UserResourceModel{Username: "jdoe", Email: "jdoe@example.com", GroupID: 3},
+			response: restclienttest.MockResponse{ExpectedMethod: "PUT", ExpectedURL: "user/7", StatusCode: 200, Response: restclient.RestResponse{Status: "success"},
+				ExpectedBody: map[string]any{"username": "jdoe", "email": "jdoe@example.com", "group_id": int64(3)}}},
+		{name: "with_password", data: UserResourceModel{Username: "jdoe", Password: "secret", GroupID: 3},
+			response: restclienttest.MockResponse{ExpectedMethod: "PUT", ExpectedURL: "user/7", StatusCode: 200, Response: restclient.RestResponse{Status: "success"},
+				ExpectedBody: map[string]any{"username": "jdoe", "email": "", "password": "secret", "group_id": int64(3)}}},
+		{name: "unknown_group", wantErr: true, data: UserResourceModel{Username: "jdoe", GroupID: 999},
+			response: restclienttest.MockResponse{ExpectedMethod: "PUT", ExpectedURL: "user/7", StatusCode: 400, Err: &restclient.APIError{StatusCode: 400, Message: "no group found with id 999"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			errorHandler := utils.NewErrorHandler(context.Background(), &diags)
+			client, err := restclienttest.NewMockedRestClient(t, []restclienttest.MockResponse{tt.response})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = UpdateUser(errorHandler, client, "7", tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdateUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("UpdateUser() diagnostics = %v, wantErr %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
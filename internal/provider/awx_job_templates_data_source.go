@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/interfaces"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &AWXJobTemplatesDataSource{}
+
+// AWXJobTemplatesDataSource defines the data source implementation.
+type AWXJobTemplatesDataSource struct {
+	config resourceOrDataSourceConfig
+}
+
+// NewAWXJobTemplatesDataSource is a helper function to simplify the provider implementation.
+func NewAWXJobTemplatesDataSource() datasource.DataSource {
+	return &AWXJobTemplatesDataSource{
+		config: resourceOrDataSourceConfig{
+			name: "awx_job_templates",
+		},
+	}
+}
+
+// AWXJobTemplatesDataSourceModel maps the data source schema data.
+type AWXJobTemplatesDataSourceModel struct {
+	CxProfileName types.String                                `tfsdk:"cx_profile_name"`
+	ID            types.String                                `tfsdk:"id"`
+	Names         []types.String                              `tfsdk:"names"`
+	JobTemplates  []AWXJobTemplatesDataSourceJobTemplateModel `tfsdk:"job_templates"`
+}
+
+// AWXJobTemplatesDataSourceJobTemplateModel maps a job template in the job templates list.
+type AWXJobTemplatesDataSourceJobTemplateModel struct {
+	ID          types.Int64  `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+// Metadata returns the data source type name.
+func (d *AWXJobTemplatesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + d.config.name
+}
+
+// Schema defines the schema for the data source.
+func (d *AWXJobTemplatesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the AWX job templates visible through Ansible Forms, with its AWX configuration. Fails when AWX is not configured.",
+
+		Attributes: map[string]schema.Attribute{
+			"cx_profile_name": schema.StringAttribute{
+				MarkdownDescription: "Connection profile name",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the list, the connection profile name.",
+				Computed:            true,
+			},
+			"names": schema.ListAttribute{
+				MarkdownDescription: "Job template names, eg to check the `template` of an awx form with `contains`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"job_templates": schema.ListNestedAttribute{
+				MarkdownDescription: "Job templates, ordered by name.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "AWX job template identifier.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Job template name.",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Job template description.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *AWXJobTemplatesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected AWX Job Templates Data Source Configure Type",
+			fmt.Sprintf("Expected Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	}
+	d.config.providerConfig = config
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *AWXJobTemplatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AWXJobTemplatesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	// we need to defer setting the client until we can read the connection profile name
+	client, err := getRestClient(errorHandler, d.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+
+	templates, err := interfaces.GetJobTemplates(errorHandler, client)
+	if err != nil {
+		// error reporting done inside GetJobTemplates
+		return
+	}
+
+	data.ID = data.CxProfileName
+	data.Names = make([]types.String, len(templates))
+	data.JobTemplates = make([]AWXJobTemplatesDataSourceJobTemplateModel, len(templates))
+	for index, template := range templates {
+		data.Names[index] = types.StringValue(template.Name)
+		data.JobTemplates[index] = AWXJobTemplatesDataSourceJobTemplateModel{
+			ID:          types.Int64Value(template.ID),
+			Name:        types.StringValue(template.Name),
+			Description: types.StringValue(template.Description),
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("read a data source: %d awx job templates", len(data.JobTemplates)))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
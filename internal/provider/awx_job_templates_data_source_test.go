@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"terraform-provider-ansible-forms/internal/fakeserver"
+)
+
+func TestAccAWXJobTemplatesDataSource(t *testing.T) {
+	server := newTestAccServer(t)
+	if server.fake == nil {
+		t.Skip("AWX may not be configured on a real server")
+	}
+	server.fake.AddJobTemplate(fakeserver.JobTemplate{Name: "Delete Share"})
+	server.fake.AddJobTemplate(fakeserver.JobTemplate{Name: "Create Share", Description: "Creates a CIFS share"})
+	config := server.providerConfig() + `
+data "ansible-forms_awx_job_templates" "templates" {
+  cx_profile_name = "cluster4"
+}`
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`awx is not configured`),
+			},
+			{
+				PreConfig: func() { server.fake.ReplaceAWX(fakeserver.AWX{URI: "https://awx.example.com", Token: "token"}) },
+				Config:    config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ansible-forms_awx_job_templates.templates", "id", "cluster4"),
+					resource.TestCheckResourceAttr("data.ansible-forms_awx_job_templates.templates", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.ansible-forms_awx_job_templates.templates", "names.0", "Create Share"),
+					resource.TestCheckResourceAttr("data.ansible-forms_awx_job_templates.templates", "job_templates.0.description", "Creates a CIFS share"),
+					resource.TestCheckResourceAttrSet("data.ansible-forms_awx_job_templates.templates", "job_templates.1.id")),
+			},
+		},
+	})
+}
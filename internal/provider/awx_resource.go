@@ -0,0 +1,249 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/interfaces"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &AWXResource{}
+	_ resource.ResourceWithConfigure   = &AWXResource{}
+	_ resource.ResourceWithImportState = &AWXResource{}
+)
+
+// NewAWXResource is a helper function to simplify the provider implementation.
+func NewAWXResource() resource.Resource {
+	return &AWXResource{
+		config: resourceOrDataSourceConfig{
+			name: "awx",
+		},
+	}
+}
+
+// AWXResource is the resource implementation.
+type AWXResource struct {
+	config resourceOrDataSourceConfig
+}
+
+// AWXResourceModel maps the resource schema data.
+type AWXResourceModel struct {
+	CxProfileName   types.String `tfsdk:"cx_profile_name"`
+	ID              types.String `tfsdk:"id"`
+	URI             types.String `tfsdk:"uri"`
+	Token           types.String `tfsdk:"token"`
+	TokenVersion    types.Int64  `tfsdk:"token_version"`
+	Username        types.String `tfsdk:"username"`
+	Password        types.String `tfsdk:"password"`
+	PasswordVersion types.Int64  `tfsdk:"password_version"`
+	IgnoreCerts     types.Bool   `tfsdk:"ignore_certs"`
+	CABundle        types.String `tfsdk:"ca_bundle"`
+}
+
+// Metadata returns the resource type name.
+func (r *AWXResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.config.name
+}
+
+// Schema defines the schema for the resource.
+func (r *AWXResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages the AWX or Ansible Tower connection of Ansible Forms, used by awx forms. There is one AWX configuration per Ansible Forms " +
+			"instance: the resource takes it over when it is created, and leaves it unchanged when it is destroyed. " +
+			"Ansible Forms authenticates with `username` and `password` when `username` is set, and with `token` otherwise.",
+
+		Attributes: map[string]schema.Attribute{
+			"cx_profile_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Connection profile name.",
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Identifier of the AWX configuration, the connection profile name.",
+			},
+			"uri": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "AWX URL, eg `https://awx.example.com`.",
+			},
+			"username": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				MarkdownDescription: "AWX user name, to authenticate with a password instead of a token.",
+			},
+			"ignore_certs": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the AWX certificate is accepted without verification.",
+			},
+			"ca_bundle": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				MarkdownDescription: "PEM encoded CA certificates used to verify the AWX certificate.",
+			},
+		},
+	}
+	maps.Copy(resp.Schema.Attributes, writeOnlySecretAttributes("token", "AWX OAuth2 token"))
+	maps.Copy(resp.Schema.Attributes, writeOnlySecretAttributes("password", "Password of `username`"))
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *AWXResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected AWX Resource Configure Type",
+			fmt.Sprintf("Expected Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	}
+	r.config.providerConfig = config
+}
+
+// awxBody returns the body of an AWX configuration request, with the token and the password when they are not null.
+func awxBody(data *AWXResourceModel, token types.String, password types.String) interfaces.AWXResourceModel {
+	return interfaces.AWXResourceModel{
+		URI:            data.URI.ValueString(),
+		Token:          token.ValueString(),
+		Username:       data.Username.ValueString(),
+		Password:       password.ValueString(),
+		UseCredentials: data.Username.ValueString() != "",
+		IgnoreCerts:    data.IgnoreCerts.ValueBool(),
+		CABundle:       data.CABundle.ValueString(),
+	}
+}
+
+// apply saves the AWX configuration.
+func (r *AWXResource) apply(errorHandler *utils.ErrorHandler, data *AWXResourceModel, token types.String, password types.String) {
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	if err = interfaces.UpdateAWX(errorHandler, client, awxBody(data, token, password)); err != nil {
+		return
+	}
+	data.ID = data.CxProfileName
+}
+
+// Create takes over the AWX configuration.
+func (r *AWXResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *AWXResourceModel
+	var token, password types.String
+
+	// Read Terraform plan data into the model, the token and the password are write-only so they are only present in the configuration
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("token"), &token)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(utils.NewErrorHandler(ctx, &resp.Diagnostics), data, token, password)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("created a awx resource: %s", data.URI.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read resource information.
+func (r *AWXResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *AWXResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	awx, err := interfaces.GetAWX(errorHandler, client)
+	if err != nil {
+		return
+	}
+	data.ID = data.CxProfileName
+	data.URI = types.StringValue(awx.URI)
+	data.Username = types.StringValue(awx.Username)
+	data.IgnoreCerts = types.BoolValue(awx.IgnoreCerts)
+	data.CABundle = types.StringValue(awx.CABundle)
+
+	tflog.Debug(ctx, fmt.Sprintf("read a awx resource: %s", data.URI.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update saves the AWX configuration, the token and the password are only sent when their version changed.
+func (r *AWXResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *AWXResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	token, diags := readWriteOnlyIfVersionChanged(ctx, req.Config, path.Root("token"), data.TokenVersion, state.TokenVersion)
+	resp.Diagnostics.Append(diags...)
+	password, diags := readWriteOnlyIfVersionChanged(ctx, req.Config, path.Root("password"), data.PasswordVersion, state.PasswordVersion)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(utils.NewErrorHandler(ctx, &resp.Diagnostics), data, token, password)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the resource from the state, the AWX configuration is left unchanged.
+func (r *AWXResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *AWXResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("awx resource removed from the state, the configuration is left unchanged: %s", data.URI.ValueString()))
+}
+
+// ImportState imports the AWX configuration of the connection profile given as identifier.
+// The token and the password are not imported, set token_version or password_version to send them on the next apply.
+func (r *AWXResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", "Expected the connection profile name as import identifier.")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cx_profile_name"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAWXResource(t *testing.T) {
+	server := newTestAccServer(t)
+	if server.fake == nil {
+		t.Skip("the AWX configuration of a real server is not replaced by acceptance tests")
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(_ *terraform.State) error {
+			// the configuration is left unchanged
+			if awx := server.fake.AWX(); awx.URI != "https://awx.example.com" {
+				return fmt.Errorf("awx uri = %s, want https://awx.example.com", awx.URI)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: server.providerConfig() + testAccAWXResourceConfig("https://awx-dev.example.com", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ansible-forms_awx.awx", "id", "cluster4"),
+					resource.TestCheckResourceAttr("ansible-forms_awx.awx", "username", ""),
+					resource.TestCheckResourceAttr("ansible-forms_awx.awx", "ignore_certs", "false"),
+					resource.TestCheckNoResourceAttr("ansible-forms_awx.awx", "token")),
+			},
+			{
+				ResourceName:      "ansible-forms_awx.awx",
+				ImportState:       true,
+				ImportStateId:     "cluster4",
+				ImportStateVerify: true,
+			},
+			{
+				// switching to a username keeps the token
+				PreConfig: func() {
+					awx := server.fake.AWX()
+					awx.Token = "kept"
+					server.fake.ReplaceAWX(awx)
+				},
+				Config: server.providerConfig() + testAccAWXResourceConfig("https://awx.example.com", "forms"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ansible-forms_awx.awx", "uri", "https://awx.example.com"),
+					resource.TestCheckResourceAttr("ansible-forms_awx.awx", "username", "forms"),
+					func(_ *terraform.State) error {
+						if awx := server.fake.AWX(); !awx.UseCredentials || awx.Token != "kept" {
+							return fmt.Errorf("awx use_credentials = %t, token changed %v, want true and the token to be kept", awx.UseCredentials, awx.Token != "kept")
+						}
+						return nil
+					}),
+			},
+		},
+	})
+}
+
+func testAccAWXResourceConfig(uri string, username string) string {
+	return fmt.Sprintf(`
+resource "ansible-forms_awx" "awx" {
+  cx_profile_name = "cluster4"
+  uri             = "%s"
+  username        = "%s"
+}`, uri, username)
+}
@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/interfaces"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &AzureADResource{}
+	_ resource.ResourceWithConfigure   = &AzureADResource{}
+	_ resource.ResourceWithImportState = &AzureADResource{}
+)
+
+// NewAzureADResource is a helper function to simplify the provider implementation.
+func NewAzureADResource() resource.Resource {
+	return &AzureADResource{
+		config: resourceOrDataSourceConfig{
+			name: "azuread",
+		},
+	}
+}
+
+// AzureADResource is the resource implementation.
+type AzureADResource struct {
+	config resourceOrDataSourceConfig
+}
+
+// AzureADResourceModel maps the resource schema data.
+type AzureADResourceModel struct {
+	CxProfileName       types.String `tfsdk:"cx_profile_name"`
+	ID                  types.String `tfsdk:"id"`
+	Enable              types.Bool   `tfsdk:"enable"`
+	ClientID            types.String `tfsdk:"client_id"`
+	ClientSecret        types.String `tfsdk:"client_secret"`
+	ClientSecretVersion types.Int64  `tfsdk:"client_secret_version"`
+	GroupFilter         types.String `tfsdk:"group_filter"`
+}
+
+// Metadata returns the resource type name.
+func (r *AzureADResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.config.name
+}
+
+// Schema defines the schema for the resource.
+func (r *AzureADResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages the Azure AD single sign-on configuration of Ansible Forms. There is one Azure AD configuration per Ansible Forms " +
+			"instance: the resource takes it over when it is created, and disables Azure AD login when it is destroyed.",
+
+		Attributes: map[string]schema.Attribute{
+			"cx_profile_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Connection profile name.",
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Identifier of the Azure AD configuration, the connection profile name.",
+			},
+			"enable": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether users can log in with Azure AD.",
+			},
+			"client_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Application (client) id of the Ansible Forms app registration.",
+			},
+			"group_filter": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				MarkdownDescription: "Regular expression selecting the groups of a user that are kept, eg `^forms_`. All the groups are kept when empty.",
+			},
+		},
+	}
+	maps.Copy(resp.Schema.Attributes, writeOnlySecretAttributes("client_secret", "Client secret of the app registration"))
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *AzureADResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Azure AD Resource Configure Type",
+			fmt.Sprintf("Expected Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	}
+	r.config.providerConfig = config
+}
+
+// azureADBody returns the body of an Azure AD configuration request, with the client secret when it is not null.
+func azureADBody(data *AzureADResourceModel, secret types.String) interfaces.AzureADResourceModel {
+	return interfaces.AzureADResourceModel{
+		ClientID:     data.ClientID.ValueString(),
+		ClientSecret: secret.ValueString(),
+		GroupFilter:  data.GroupFilter.ValueString(),
+		Enable:       data.Enable.ValueBool(),
+	}
+}
+
+// apply saves the Azure AD configuration.
+func (r *AzureADResource) apply(errorHandler *utils.ErrorHandler, data *AzureADResourceModel, secret types.String) {
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	if err = interfaces.UpdateAzureAD(errorHandler, client, azureADBody(data, secret)); err != nil {
+		return
+	}
+	data.ID = data.CxProfileName
+}
+
+// Create takes over the Azure AD configuration.
+func (r *AzureADResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *AzureADResourceModel
+	var secret types.String
+
+	// Read Terraform plan data into the model, the client secret is write-only so it is only present in the configuration
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("client_secret"), &secret)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(utils.NewErrorHandler(ctx, &resp.Diagnostics), data, secret)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("created a azuread resource: %s", data.ClientID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read resource information.
+func (r *AzureADResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *AzureADResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	azureAD, err := interfaces.GetAzureAD(errorHandler, client)
+	if err != nil {
+		return
+	}
+	data.ID = data.CxProfileName
+	data.Enable = types.BoolValue(azureAD.Enable)
+	data.ClientID = types.StringValue(azureAD.ClientID)
+	data.GroupFilter = types.StringValue(azureAD.GroupFilter)
+
+	tflog.Debug(ctx, fmt.Sprintf("read a azuread resource: %s, enable %t", data.ClientID.ValueString(), azureAD.Enable))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update saves the Azure AD configuration, the client secret is only sent when client_secret_version changed.
+func (r *AzureADResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *AzureADResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	secret, diags := readWriteOnlyIfVersionChanged(ctx, req.Config, path.Root("client_secret"), data.ClientSecretVersion, state.ClientSecretVersion)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(utils.NewErrorHandler(ctx, &resp.Diagnostics), data, secret)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete disables Azure AD login, the other settings are left unchanged.
+func (r *AzureADResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *AzureADResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	data.Enable = types.BoolValue(false)
+	_ = interfaces.UpdateAzureAD(errorHandler, client, azureADBody(data, types.StringNull()))
+}
+
+// ImportState imports the Azure AD configuration of the connection profile given as identifier.
+// The client secret is not imported, set client_secret_version to send it on the next apply.
+func (r *AzureADResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", "Expected the connection profile name as import identifier.")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cx_profile_name"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
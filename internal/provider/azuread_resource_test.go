@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAzureADResource(t *testing.T) {
+	server := newTestAccServer(t)
+	if server.fake == nil {
+		t.Skip("the Azure AD configuration of a real server is not replaced by acceptance tests")
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(_ *terraform.State) error {
+			if azureAD := server.fake.AzureAD(); azureAD.Enable || azureAD.ClientID == "" {
+				return fmt.Errorf("azuread enable = %t, client_id = %s, want false and the client id to be kept", azureAD.Enable, azureAD.ClientID)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: server.providerConfig() + testAccAzureADResourceConfig(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ansible-forms_azuread.azuread", "id", "cluster4"),
+					resource.TestCheckResourceAttr("ansible-forms_azuread.azuread", "enable", "true"),
+					resource.TestCheckResourceAttr("ansible-forms_azuread.azuread", "group_filter", ""),
+					resource.TestCheckNoResourceAttr("ansible-forms_azuread.azuread", "client_secret")),
+			},
+			{
+				ResourceName:      "ansible-forms_azuread.azuread",
+				ImportState:       true,
+				ImportStateId:     "cluster4",
+				ImportStateVerify: true,
+			},
+			{
+				// the configuration is changed outside of Terraform, the change is reverted and the secret is kept
+				PreConfig: func() {
+					azureAD := server.fake.AzureAD()
+					azureAD.Enable = false
+					azureAD.ClientSecret = "kept"
+					server.fake.ReplaceAzureAD(azureAD)
+				},
+				Config: server.providerConfig() + testAccAzureADResourceConfig("^forms_"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ansible-forms_azuread.azuread", "group_filter", "^forms_"),
+					func(_ *terraform.State) error {
+						if azureAD := server.fake.AzureAD(); !azureAD.Enable || azureAD.ClientSecret != "kept" {
+							return fmt.Errorf("azuread enable = %t, secret changed %v, want true and the secret to be kept", azureAD.Enable, azureAD.ClientSecret != "kept")
+						}
+						return nil
+					}),
+			},
+		},
+	})
+}
+
+func testAccAzureADResourceConfig(groupFilter string) string {
+	return fmt.Sprintf(`
+resource "ansible-forms_azuread" "azuread" {
+  cx_profile_name = "cluster4"
+  client_id       = "6f1c2a4e-0000-4000-8000-000000000001"
+  group_filter    = "%s"
+}`, groupFilter)
+}
@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/interfaces"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &CategoryResource{}
+	_ resource.ResourceWithConfigure   = &CategoryResource{}
+	_ resource.ResourceWithImportState = &CategoryResource{}
+)
+
+// NewCategoryResource is a helper function to simplify the provider implementation.
+func NewCategoryResource() resource.Resource {
+	return &CategoryResource{
+		config: resourceOrDataSourceConfig{
+			name: "category",
+		},
+	}
+}
+
+// CategoryResource is the resource implementation.
+type CategoryResource struct {
+	config resourceOrDataSourceConfig
+}
+
+// CategoryResourceModel maps the resource schema data.
+type CategoryResourceModel struct {
+	CxProfileName types.String                  `tfsdk:"cx_profile_name"`
+	Name          types.String                  `tfsdk:"name"`
+	ID            types.String                  `tfsdk:"id"`
+	Icon          types.String                  `tfsdk:"icon"`
+	Categories    []CategoryResourceNestedModel `tfsdk:"categories"`
+}
+
+// CategoryResourceNestedModel maps a nested category.
+type CategoryResourceNestedModel struct {
+	Name types.String `tfsdk:"name"`
+	Icon types.String `tfsdk:"icon"`
+}
+
+// Metadata returns the resource type name.
+func (r *CategoryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.config.name
+}
+
+// Schema defines the schema for the resource.
+func (r *CategoryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a top level category of the forms configuration, shown as a tile in the Ansible Forms UI. " +
+			"Keys of the category that are not managed by the resource, such as the items of nested categories, are kept on update. " +
+			"The forms configuration is saved with optimistic locking, like for `ansible-forms_form`, so that other categories and forms are not lost.",
+
+		Attributes: map[string]schema.Attribute{
+			"cx_profile_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Connection profile name.",
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "Category name, unique among the top level categories. Forms refer to it in `categories`.",
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Identifier of the category, the category name.",
+			},
+			"icon": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Font Awesome icon of the tile, eg `database`.",
+			},
+			"categories": schema.ListNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Nested categories, shown inside the category tile, saved as `items` in the forms configuration. " +
+					"Nested categories are matched by name, their own `items` and other keys are kept.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Nested category name.",
+						},
+						"icon": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Font Awesome icon of the tile.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *CategoryResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Category Resource Configure Type",
+			fmt.Sprintf("Expected Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	}
+	r.config.providerConfig = config
+}
+
+// categoryDefinition returns the category definition described by data, as found in the forms configuration.
+func categoryDefinition(data *CategoryResourceModel) map[string]any {
+	category := map[string]any{"name": data.Name.ValueString()}
+	if !data.Icon.IsNull() {
+		category["icon"] = data.Icon.ValueString()
+	}
+	if data.Categories != nil {
+		items := make([]any, len(data.Categories))
+		for index, nested := range data.Categories {
+			item := map[string]any{"name": nested.Name.ValueString()}
+			if !nested.Icon.IsNull() {
+				item["icon"] = nested.Icon.ValueString()
+			}
+			items[index] = item
+		}
+		category["items"] = items
+	}
+	return category
+}
+
+// setCategoryResourceModel copies the server category definition into data.
+func setCategoryResourceModel(data *CategoryResourceModel, category map[string]any) {
+	data.ID = data.Name
+	data.Icon = anyToTypesString(category["icon"])
+	items, ok := category["items"].([]any)
+	if !ok {
+		data.Categories = nil
+		return
+	}
+	data.Categories = make([]CategoryResourceNestedModel, 0, len(items))
+	for _, element := range items {
+		item, _ := element.(map[string]any)
+		data.Categories = append(data.Categories, CategoryResourceNestedModel{
+			Name: anyToTypesString(item["name"]),
+			Icon: anyToTypesString(item["icon"]),
+		})
+	}
+}
+
+// Create a new resource.
+func (r *CategoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *CategoryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	if err = interfaces.CreateCategoryDefinition(errorHandler, client, categoryDefinition(data)); err != nil {
+		return
+	}
+	data.ID = data.Name
+
+	tflog.Trace(ctx, fmt.Sprintf("created a category resource: %s", data.Name.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read resource information.
+func (r *CategoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *CategoryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	category, err := interfaces.GetCategoryDefinition(errorHandler, client, data.Name.ValueString())
+	if err != nil {
+		return
+	}
+	if category == nil {
+		resp.Diagnostics.AddWarning("category not found",
+			fmt.Sprintf("Category %s no longer exists in Ansible Forms, it is removed from the state and will be created again on the next apply.", data.Name.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	setCategoryResourceModel(data, category)
+
+	tflog.Debug(ctx, fmt.Sprintf("read a category resource: %s", data.Name.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update replaces the category definition.
+func (r *CategoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *CategoryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	if err = interfaces.UpdateCategoryDefinition(errorHandler, client, categoryDefinition(data)); err != nil {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the category from the forms configuration.
+func (r *CategoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *CategoryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	_ = interfaces.DeleteCategoryDefinition(errorHandler, client, data.Name.ValueString())
+}
+
+// ImportState imports a category with an identifier in the format name,cx_profile_name.
+func (r *CategoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Debug(ctx, fmt.Sprintf("import req a category resource: %#v", req))
+	// category names may contain commas, the connection profile name is after the last one
+	index := strings.LastIndex(req.ID, ",")
+	if index <= 0 || index == len(req.ID)-1 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: name,cx_profile_name. Got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID[:index])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cx_profile_name"), req.ID[index+1:])...)
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCategoryResource(t *testing.T) {
+	server := newTestAccServer(t)
+	steps := []resource.TestStep{
+		{
+			Config: server.providerConfig() + testAccCategoryResourceConfig("database", `{ name = "NetApp", icon = "server" }`),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("ansible-forms_category.category", "id", "TF Storage"),
+				resource.TestCheckResourceAttr("ansible-forms_category.category", "icon", "database"),
+				resource.TestCheckResourceAttr("ansible-forms_category.category", "categories.0.name", "NetApp"),
+				testAccCheckFakeCategoryDefinition(server, "TF Storage", "icon", "database")),
+		},
+		{
+			ResourceName:      "ansible-forms_category.category",
+			ImportState:       true,
+			ImportStateId:     "TF Storage,cluster4",
+			ImportStateVerify: true,
+		},
+		{
+			Config: server.providerConfig() + testAccCategoryResourceConfig("hdd", `{ name = "NetApp", icon = "server" }, { name = "Pure" }`),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("ansible-forms_category.category", "icon", "hdd"),
+				resource.TestCheckResourceAttr("ansible-forms_category.category", "categories.#", "2"),
+				resource.TestCheckNoResourceAttr("ansible-forms_category.category", "categories.1.icon"),
+				testAccCheckFakeCategoryDefinition(server, "TF Storage", "icon", "hdd")),
+		},
+	}
+	var checkDestroy resource.TestCheckFunc
+	if server.fake != nil {
+		checkDestroy = func(_ *terraform.State) error {
+			if _, ok := server.fake.CategoryDefinition("TF Storage"); ok {
+				return fmt.Errorf("category TF Storage still exists")
+			}
+			if _, ok := server.fake.CategoryDefinition("Default"); !ok {
+				return fmt.Errorf("category Default was deleted")
+			}
+			return nil
+		}
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             checkDestroy,
+		Steps:                    steps,
+	})
+}
+
+// testAccCheckFakeCategoryDefinition checks a key of a category in the fake Ansible Forms server.
+func testAccCheckFakeCategoryDefinition(server testAccServer, name string, key string, want string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if server.fake == nil {
+			return nil
+		}
+		category, ok := server.fake.CategoryDefinition(name)
+		if !ok {
+			return fmt.Errorf("category %s not found", name)
+		}
+		if category[key] != want {
+			return fmt.Errorf("category %s %s = %v, want %s", name, key, category[key], want)
+		}
+		return nil
+	}
+}
+
+func testAccCategoryResourceConfig(icon string, categories string) string {
+	return fmt.Sprintf(`
+resource "ansible-forms_category" "category" {
+  cx_profile_name = "cluster4"
+  name            = "TF Storage"
+  icon            = "%s"
+  categories      = [%s]
+}`, icon, categories)
+}
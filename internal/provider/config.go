@@ -3,11 +3,13 @@ package provider
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/mitchellh/mapstructure"
 	"golang.org/x/exp/maps"
 
+	"terraform-provider-ansible-forms/internal/interfaces"
 	"terraform-provider-ansible-forms/internal/restclient"
 	"terraform-provider-ansible-forms/internal/utils"
 )
@@ -19,8 +21,13 @@ type ConnectionProfile struct {
 	Hostname              string
 	Username              string
 	Password              string
+	Token                 string
 	ValidateCerts         bool
+	ProxyURL              string
+	NoProxy               string
 	MaxConcurrentRequests int
+	// Secrets lists values to mask in logs in addition to Password and Token, eg the password of ProxyURL.
+	Secrets []string
 }
 
 // Config is created by the provide configure method
@@ -28,6 +35,88 @@ type Config struct {
 	ConnectionProfiles   map[string]ConnectionProfile
 	Version              string
 	JobCompletionTimeOut int
+	// SensitiveLogKeys lists JSON keys to redact from logs, in addition to utils.DefaultSensitiveKeys.
+	SensitiveLogKeys []string
+	// client is used instead of a new client when set, so that resources and data sources can be unit tested
+	client restclient.Client
+	// forms caches the form definitions read at plan time, it is shared by all the resources of the provider
+	forms *formCache
+	// credentials caches the credential names read at plan time, it is shared by all the resources of the provider
+	credentials *credentialCache
+}
+
+// formCache keeps form definitions per connection profile and form name.
+type formCache struct {
+	mutex sync.Mutex
+	forms map[string]*interfaces.FormGetDataSourceModel
+}
+
+// newFormCache returns an empty form cache.
+func newFormCache() *formCache {
+	return &formCache{forms: map[string]*interfaces.FormGetDataSourceModel{}}
+}
+
+// GetForm returns the form formName of the connection profile cxProfileName, reading it only once per provider.
+// It returns nil without error when the form does not exist.
+func (c *Config) GetForm(errorHandler *utils.ErrorHandler, client restclient.Client, cxProfileName string, formName string) (*interfaces.FormGetDataSourceModel, error) {
+	if c.forms == nil {
+		return interfaces.GetFormByName(errorHandler, client, formName)
+	}
+	key := cxProfileName + "\x00" + formName
+	c.forms.mutex.Lock()
+	defer c.forms.mutex.Unlock()
+	if form, ok := c.forms.forms[key]; ok {
+		return form, nil
+	}
+	form, err := interfaces.GetFormByName(errorHandler, client, formName)
+	if err != nil {
+		return nil, err
+	}
+	c.forms.forms[key] = form
+
+	return form, nil
+}
+
+// credentialCache keeps credential names per connection profile.
+type credentialCache struct {
+	mutex sync.Mutex
+	names map[string]map[string]bool
+}
+
+// newCredentialCache returns an empty credential cache.
+func newCredentialCache() *credentialCache {
+	return &credentialCache{names: map[string]map[string]bool{}}
+}
+
+// GetCredentialNames returns the names of the credentials of the connection profile cxProfileName, reading them only
+// once per provider.
+func (c *Config) GetCredentialNames(errorHandler *utils.ErrorHandler, client restclient.Client, cxProfileName string) (map[string]bool, error) {
+	read := func() (map[string]bool, error) {
+		credentials, err := interfaces.GetCredentials(errorHandler, client)
+		if err != nil {
+			return nil, err
+		}
+		names := make(map[string]bool, len(credentials))
+		for _, credential := range credentials {
+			names[credential.Name] = true
+		}
+		return names, nil
+	}
+	if c.credentials == nil {
+		return read()
+	}
+	c.credentials.mutex.Lock()
+	defer c.credentials.mutex.Unlock()
+	if names, ok := c.credentials.names[cxProfileName]; ok {
+		return names, nil
+	}
+	names, err := read()
+	if err != nil {
+		return nil, err
+	}
+	c.credentials.names[cxProfileName] = names
+
+	return names, nil
 }
 
 // GetConnectionProfile retrieves a connection profile based on name
@@ -52,7 +141,10 @@ func (c *Config) GetConnectionProfile(name string) (*ConnectionProfile, error) {
 }
 
 // NewClient creates a RestClient based on the connection profile identified by cxProfileName
-func (c *Config) NewClient(errorHandler *utils.ErrorHandler, cxProfileName string, resName string) (*restclient.RestClient, error) {
+func (c *Config) NewClient(errorHandler *utils.ErrorHandler, cxProfileName string, resName string) (restclient.Client, error) {
+	if c.client != nil {
+		return c.client, nil
+	}
 	connectionProfile, err := c.GetConnectionProfile(cxProfileName)
 	if err != nil {
 		return nil, errorHandler.MakeAndReportError("failed to set connection profile", err.Error())
@@ -61,8 +153,9 @@ func (c *Config) NewClient(errorHandler *utils.ErrorHandler, cxProfileName strin
 	err = mapstructure.Decode(connectionProfile, &profile)
 	if err != nil {
 		return nil, errorHandler.MakeAndReportError("unable to create REST client",
-			fmt.Sprintf("decode error on ConnectionProfile %#v to restclient.ConnectionProfile", connectionProfile))
+			fmt.Sprintf("decode error on ConnectionProfile for %s to restclient.ConnectionProfile: %s", connectionProfile.Hostname, err))
 	}
+	profile.SensitiveKeys = c.SensitiveLogKeys
 	// the tag resource_name/version will be used for telemetry
 
 	tflog.Debug(errorHandler.Ctx, fmt.Sprintf("Version string is: %#v", strings.Join([]string{"TerrafromONTAP", resName, c.Version}, "/")))
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// credentialCommandTimeout bounds how long a credential command may run.
+const credentialCommandTimeout = 60 * time.Second
+
+// credentialCommandStderrLimit bounds how much of the stderr of a failed credential command is reported.
+const credentialCommandStderrLimit = 512
+
+// credentialCommandOutput is the JSON document a credential command prints on stdout.
+// Either username and password, or token, are expected.
+type credentialCommandOutput struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Token    string `json:"token"`
+}
+
+// credentialCommandEntry is the result of a command, its lock is held while the command runs so that concurrent
+// configurations wait for it instead of running it again.
+type credentialCommandEntry struct {
+	sync.Mutex
+	output *credentialCommandOutput
+}
+
+// credentialCommandCache keeps the result of each command for the lifetime of the provider process,
+// so the command is executed at most once even if the provider is configured several times.
+// The cache lock only guards the map, each command is locked on its own entry.
+var credentialCommandCache = struct {
+	sync.Mutex
+	entries map[string]*credentialCommandEntry
+}{entries: map[string]*credentialCommandEntry{}}
+
+// runCredentialCommand executes argv (without a shell) and decodes its output.
+// The output is never logged, and errors do not include it.
+func runCredentialCommand(ctx context.Context, argv []string) (credentialCommandOutput, error) {
+	if len(argv) == 0 || argv[0] == "" {
+		return credentialCommandOutput{}, errors.New("credential_command requires at least the program to execute")
+	}
+	key := strings.Join(argv, "\x00")
+
+	credentialCommandCache.Lock()
+	entry, ok := credentialCommandCache.entries[key]
+	if !ok {
+		entry = &credentialCommandEntry{}
+		credentialCommandCache.entries[key] = entry
+	}
+	credentialCommandCache.Unlock()
+
+	// a failed command is not cached, it runs again on the next configuration
+	entry.Lock()
+	defer entry.Unlock()
+	if entry.output != nil {
+		tflog.Debug(ctx, fmt.Sprintf("using cached output of credential command %s", argv[0]))
+		return *entry.output, nil
+	}
+
+	cmdCtx, cancel := context.WithTimeout(ctx, credentialCommandTimeout)
+	defer cancel()
+	// #nosec G204 -- the command is provided by the operator in the provider configuration and is not run by a shell.
+	cmd := exec.CommandContext(cmdCtx, argv[0], argv[1:]...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	tflog.Debug(ctx, fmt.Sprintf("running credential command %s", argv[0]))
+	if err := cmd.Run(); err != nil {
+		return credentialCommandOutput{}, fmt.Errorf("credential command %s failed: %s, stderr: %s", argv[0], err, redactStderr(stderr.String()))
+	}
+
+	var output credentialCommandOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return credentialCommandOutput{}, fmt.Errorf("credential command %s did not print a valid JSON document: %s", argv[0], err)
+	}
+	if output.Token == "" && (output.Username == "" || output.Password == "") {
+		return credentialCommandOutput{}, fmt.Errorf("credential command %s must print either username and password, or token", argv[0])
+	}
+	entry.output = &output
+
+	return output, nil
+}
+
+// urlUserInfo matches the user info of the URLs in a text, that may hold a token.
+var urlUserInfo = regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://)[^\s/@]+@`)
+
+// redactStderr returns the stderr of a failed command for a diagnostic: the command may print secrets, so sensitive
+// JSON keys and the user info of URLs are redacted, and it is truncated to credentialCommandStderrLimit bytes.
+func redactStderr(stderr string) string {
+	redacted, _ := utils.NewRedactor(nil).Value(strings.TrimSpace(stderr)).(string)
+	redacted = urlUserInfo.ReplaceAllString(redacted, "${1}"+utils.RedactedValue+"@")
+	if len(redacted) > credentialCommandStderrLimit {
+		redacted = strings.ToValidUTF8(redacted[:credentialCommandStderrLimit], "") + "... (truncated)"
+	}
+	return redacted
+}
+
+// secrets returns the non empty secret values, to be masked in logs.
+func (o credentialCommandOutput) secrets() []string {
+	var secrets []string
+	for _, secret := range []string{o.Password, o.Token} {
+		if secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	return secrets
+}
@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/interfaces"
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &CredentialResource{}
+	_ resource.ResourceWithConfigure   = &CredentialResource{}
+	_ resource.ResourceWithImportState = &CredentialResource{}
+)
+
+// NewCredentialResource is a helper function to simplify the provider implementation.
+func NewCredentialResource() resource.Resource {
+	return &CredentialResource{
+		config: resourceOrDataSourceConfig{
+			name: "credential",
+		},
+	}
+}
+
+// CredentialResource is the resource implementation.
+type CredentialResource struct {
+	config resourceOrDataSourceConfig
+}
+
+// CredentialResourceModel maps the resource schema data.
+type CredentialResourceModel struct {
+	CxProfileName   types.String `tfsdk:"cx_profile_name"`
+	Name            types.String `tfsdk:"name"`
+	ID              types.String `tfsdk:"id"`
+	Host            types.String `tfsdk:"host"`
+	User            types.String `tfsdk:"user"`
+	Password        types.String `tfsdk:"password"`
+	PasswordVersion types.Int64  `tfsdk:"password_version"`
+	Description     types.String `tfsdk:"description"`
+	Secure          types.Bool   `tfsdk:"secure"`
+}
+
+// Metadata returns the resource type name.
+func (r *CredentialResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.config.name
+}
+
+// Schema defines the schema for the resource.
+func (r *CredentialResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a credential stored in Ansible Forms, that jobs refer to by name in `credentials`. " +
+			"Ansible Forms never returns the password, so changes to the password made outside of Terraform are not detected.",
+
+		Attributes: map[string]schema.Attribute{
+			"cx_profile_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Connection profile name.",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Credential name, unique in Ansible Forms.",
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Credential identifier.",
+			},
+			"host": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Host the credential is used for.",
+			},
+			"user": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "User name.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				MarkdownDescription: "Credential description.",
+			},
+			"secure": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the connection to the host uses TLS.",
+			},
+		},
+	}
+	maps.Copy(resp.Schema.Attributes, writeOnlySecretAttributes("password", "Password"))
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *CredentialResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Credential Resource Configure Type",
+			fmt.Sprintf("Expected Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	}
+	r.config.providerConfig = config
+}
+
+// credentialBody returns the body of a credential request, with password when it is not null.
+func credentialBody(data *CredentialResourceModel, password types.String) interfaces.CredentialResourceModel {
+	return interfaces.CredentialResourceModel{
+		Name:        data.Name.ValueString(),
+		Host:        data.Host.ValueString(),
+		User:        data.User.ValueString(),
+		Password:    password.ValueString(),
+		Description: data.Description.ValueString(),
+		Secure:      data.Secure.ValueBool(),
+	}
+}
+
+// Create a new resource.
+func (r *CredentialResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *CredentialResourceModel
+	var password types.String
+
+	// Read Terraform plan data into the model, password is write-only so it is only present in the configuration
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	id, err := interfaces.CreateCredential(errorHandler, client, credentialBody(data, password))
+	if err != nil {
+		return
+	}
+	data.ID = types.StringValue(strconv.FormatInt(id, 10))
+
+	tflog.Trace(ctx, fmt.Sprintf("created a credential resource: %s, id %s", data.Name.ValueString(), data.ID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findCredential returns the credential of data, by id, or by name after an import.
+func (r *CredentialResource) findCredential(errorHandler *utils.ErrorHandler, client restclient.Client, data *CredentialResourceModel) (*interfaces.CredentialGetDataSourceModel, error) {
+	if data.ID.IsNull() {
+		return interfaces.GetCredentialByName(errorHandler, client, data.Name.ValueString())
+	}
+	return interfaces.GetCredentialByID(errorHandler, client, data.ID.ValueString())
+}
+
+// Read resource information.
+func (r *CredentialResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *CredentialResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	credential, err := r.findCredential(errorHandler, client, data)
+	if err != nil {
+		return
+	}
+	if credential == nil {
+		resp.Diagnostics.AddWarning("credential not found",
+			fmt.Sprintf("Credential %s no longer exists in Ansible Forms, it is removed from the state and will be created again on the next apply.", data.Name.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.ID = types.StringValue(strconv.FormatInt(credential.ID, 10))
+	data.Name = types.StringValue(credential.Name)
+	data.Host = types.StringValue(credential.Host)
+	data.User = types.StringValue(credential.User)
+	data.Description = types.StringValue(credential.Description)
+	data.Secure = types.BoolValue(credential.Secure)
+
+	tflog.Debug(ctx, fmt.Sprintf("read a credential resource: %s, id %s", data.Name.ValueString(), data.ID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates the credential, the password is only sent when password_version changed.
+func (r *CredentialResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *CredentialResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	password, diags := readWriteOnlyIfVersionChanged(ctx, req.Config, path.Root("password"), data.PasswordVersion, state.PasswordVersion)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	if err = interfaces.UpdateCredential(errorHandler, client, data.ID.ValueString(), credentialBody(data, password)); err != nil {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes the credential.
+func (r *CredentialResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *CredentialResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	_ = interfaces.DeleteCredentialByID(errorHandler, client, data.ID.ValueString())
+}
+
+// ImportState imports a credential with an identifier in the format name,cx_profile_name.
+// The password is not imported, set password_version to send it on the next apply.
+func (r *CredentialResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Debug(ctx, fmt.Sprintf("import req a credential resource: %#v", req))
+	// credential names may contain commas, the connection profile name is after the last one
+	index := strings.LastIndex(req.ID, ",")
+	if index <= 0 || index == len(req.ID)-1 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: name,cx_profile_name. Got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID[:index])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cx_profile_name"), req.ID[index+1:])...)
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCredentialResource(t *testing.T) {
+	server := newTestAccServer(t)
+	steps := []resource.TestStep{
+		{
+			Config: server.providerConfig() + testAccCredentialResourceConfig("tf_ontap_cred", "ontap1.example.com"),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("ansible-forms_credential.credential", "id"),
+				resource.TestCheckResourceAttr("ansible-forms_credential.credential", "description", "ONTAP cluster admin"),
+				resource.TestCheckResourceAttr("ansible-forms_credential.credential", "secure", "true"),
+				resource.TestCheckNoResourceAttr("ansible-forms_credential.credential", "password"),
+				testAccCheckFakeCredential(server, "tf_ontap_cred", "ontap1.example.com")),
+		},
+		{
+			ResourceName:      "ansible-forms_credential.credential",
+			ImportState:       true,
+			ImportStateId:     "tf_ontap_cred,cluster4",
+			ImportStateVerify: true,
+		},
+		{
+			Config: server.providerConfig() + testAccCredentialResourceConfig("tf_ontap_cred_renamed", "ontap2.example.com"),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("ansible-forms_credential.credential", "name", "tf_ontap_cred_renamed"),
+				testAccCheckFakeCredential(server, "tf_ontap_cred_renamed", "ontap2.example.com")),
+		},
+		{
+			// write-only attributes require Terraform 1.11
+			SkipFunc: testAccSkipBelowTerraform("1.11.0"),
+			Config:   server.providerConfig() + testAccCredentialResourceConfigWithPassword("tf_ontap_cred_renamed", "s3cr3t", 1),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckNoResourceAttr("ansible-forms_credential.credential", "password"),
+				resource.TestCheckResourceAttr("ansible-forms_credential.credential", "password_version", "1"),
+				testAccCheckFakeCredentialPassword(server, "tf_ontap_cred_renamed", "s3cr3t")),
+		},
+		{
+			// the password is only sent again when password_version changes
+			SkipFunc: testAccSkipBelowTerraform("1.11.0"),
+			Config:   server.providerConfig() + testAccCredentialResourceConfigWithPassword("tf_ontap_cred_renamed", "ignored", 1),
+			Check:    testAccCheckFakeCredentialPassword(server, "tf_ontap_cred_renamed", "s3cr3t"),
+		},
+		{
+			SkipFunc: testAccSkipBelowTerraform("1.11.0"),
+			Config:   server.providerConfig() + testAccCredentialResourceConfigWithPassword("tf_ontap_cred_renamed", "r0tated", 2),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("ansible-forms_credential.credential", "password_version", "2"),
+				testAccCheckFakeCredentialPassword(server, "tf_ontap_cred_renamed", "r0tated")),
+		},
+	}
+	var checkDestroy resource.TestCheckFunc
+	if server.fake != nil {
+		steps = append(steps, resource.TestStep{
+			// the credential is changed outside of Terraform, the change is reverted and the password is kept
+			PreConfig: func() {
+				credential, _ := server.fake.CredentialByName("tf_ontap_cred_renamed")
+				credential.User = "changed"
+				credential.Password = "kept"
+				server.fake.ReplaceCredential(credential)
+			},
+			Config: server.providerConfig() + testAccCredentialResourceConfig("tf_ontap_cred_renamed", "ontap2.example.com"),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("ansible-forms_credential.credential", "user", "admin"),
+				func(_ *terraform.State) error {
+					credential, _ := server.fake.CredentialByName("tf_ontap_cred_renamed")
+					if credential.User != "admin" || credential.Password != "kept" {
+						return fmt.Errorf("credential user = %s, password changed %v, want admin and the password to be kept", credential.User, credential.Password != "kept")
+					}
+					return nil
+				}),
+		})
+		checkDestroy = func(_ *terraform.State) error {
+			if _, ok := server.fake.CredentialByName("tf_ontap_cred_renamed"); ok {
+				return fmt.Errorf("credential tf_ontap_cred_renamed still exists")
+			}
+			return nil
+		}
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             checkDestroy,
+		Steps:                    steps,
+	})
+}
+
+// testAccCheckFakeCredential checks the host of a credential in the fake Ansible Forms server.
+func testAccCheckFakeCredential(server testAccServer, name string, host string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if server.fake == nil {
+			return nil
+		}
+		credential, ok := server.fake.CredentialByName(name)
+		if !ok {
+			return fmt.Errorf("credential %s not found", name)
+		}
+		if credential.Host != host {
+			return fmt.Errorf("credential %s host = %s, want %s", name, credential.Host, host)
+		}
+		return nil
+	}
+}
+
+// testAccCheckFakeCredentialPassword checks the password of a credential in the fake Ansible Forms server.
+func testAccCheckFakeCredentialPassword(server testAccServer, name string, password string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if server.fake == nil {
+			return nil
+		}
+		credential, ok := server.fake.CredentialByName(name)
+		if !ok {
+			return fmt.Errorf("credential %s not found", name)
+		}
+		if credential.Password != password {
+			return fmt.Errorf("credential %s password was not updated as expected", name)
+		}
+		return nil
+	}
+}
+
+func testAccCredentialResourceConfigWithPassword(name string, password string, passwordVersion int) string {
+	return fmt.Sprintf(`
+resource "ansible-forms_credential" "credential" {
+  cx_profile_name  = "cluster4"
+  name             = "%s"
+  host             = "ontap2.example.com"
+  user             = "admin"
+  description      = "ONTAP cluster admin"
+  secure           = true
+  password         = "%s"
+  password_version = %d
+}`, name, password, passwordVersion)
+}
+
+func testAccCredentialResourceConfig(name string, host string) string {
+	return fmt.Sprintf(`
+resource "ansible-forms_credential" "credential" {
+  cx_profile_name = "cluster4"
+  name            = "%s"
+  host            = "%s"
+  user            = "admin"
+  description     = "ONTAP cluster admin"
+  secure          = true
+}`, name, host)
+}
@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/interfaces"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &CredentialsDataSource{}
+
+// CredentialsDataSource defines the data source implementation.
+type CredentialsDataSource struct {
+	config resourceOrDataSourceConfig
+}
+
+// NewCredentialsDataSource is a helper function to simplify the provider implementation.
+func NewCredentialsDataSource() datasource.DataSource {
+	return &CredentialsDataSource{
+		config: resourceOrDataSourceConfig{
+			name: "credentials",
+		},
+	}
+}
+
+// CredentialsDataSourceModel maps the data source schema data.
+type CredentialsDataSourceModel struct {
+	CxProfileName types.String                           `tfsdk:"cx_profile_name"`
+	ID            types.String                           `tfsdk:"id"`
+	Names         []types.String                         `tfsdk:"names"`
+	Credentials   []CredentialsDataSourceCredentialModel `tfsdk:"credentials"`
+}
+
+// CredentialsDataSourceCredentialModel maps a credential in the credentials list.
+type CredentialsDataSourceCredentialModel struct {
+	ID          types.Int64  `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Host        types.String `tfsdk:"host"`
+	User        types.String `tfsdk:"user"`
+	Description types.String `tfsdk:"description"`
+	Secure      types.Bool   `tfsdk:"secure"`
+}
+
+// Metadata returns the data source type name.
+func (d *CredentialsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + d.config.name
+}
+
+// Schema defines the schema for the data source.
+func (d *CredentialsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the credentials stored in Ansible Forms. Passwords are never returned.",
+
+		Attributes: map[string]schema.Attribute{
+			"cx_profile_name": schema.StringAttribute{
+				MarkdownDescription: "Connection profile name",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the list, the connection profile name.",
+				Computed:            true,
+			},
+			"names": schema.ListAttribute{
+				MarkdownDescription: "Credential names, eg to check the credentials of a job with `contains`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"credentials": schema.ListNestedAttribute{
+				MarkdownDescription: "Credentials, ordered by id.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Credential identifier.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Credential name.",
+							Computed:            true,
+						},
+						"host": schema.StringAttribute{
+							MarkdownDescription: "Host the credential is used for.",
+							Computed:            true,
+						},
+						"user": schema.StringAttribute{
+							MarkdownDescription: "User name.",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Credential description.",
+							Computed:            true,
+						},
+						"secure": schema.BoolAttribute{
+							MarkdownDescription: "Whether the connection to the host uses TLS.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *CredentialsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Credentials Data Source Configure Type",
+			fmt.Sprintf("Expected Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	}
+	d.config.providerConfig = config
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *CredentialsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CredentialsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	// we need to defer setting the client until we can read the connection profile name
+	client, err := getRestClient(errorHandler, d.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+
+	credentials, err := interfaces.GetCredentials(errorHandler, client)
+	if err != nil {
+		// error reporting done inside GetCredentials
+		return
+	}
+
+	data.ID = data.CxProfileName
+	data.Names = make([]types.String, len(credentials))
+	data.Credentials = make([]CredentialsDataSourceCredentialModel, len(credentials))
+	for index, credential := range credentials {
+		data.Names[index] = types.StringValue(credential.Name)
+		data.Credentials[index] = CredentialsDataSourceCredentialModel{
+			ID:          types.Int64Value(credential.ID),
+			Name:        types.StringValue(credential.Name),
+			Host:        types.StringValue(credential.Host),
+			User:        types.StringValue(credential.User),
+			Description: types.StringValue(credential.Description),
+			Secure:      types.BoolValue(credential.Secure),
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("read a data source: %d credentials", len(data.Credentials)))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"terraform-provider-ansible-forms/internal/fakeserver"
+)
+
+func TestAccCredentialsDataSource(t *testing.T) {
+	server := newTestAccServer(t)
+	check := resource.TestCheckResourceAttrSet("data.ansible-forms_credentials.credentials", "credentials.#")
+	if server.fake != nil {
+		server.fake.AddCredential(fakeserver.Credential{Name: "ontap_cred", Host: "ontap1.example.com", User: "admin", Password: "secret", Description: "ONTAP cluster admin", Secure: true})
+		check = resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr("data.ansible-forms_credentials.credentials", "id", "cluster4"),
+			resource.TestCheckResourceAttr("data.ansible-forms_credentials.credentials", "names.#", "1"),
+			resource.TestCheckResourceAttr("data.ansible-forms_credentials.credentials", "names.0", "ontap_cred"),
+			resource.TestCheckResourceAttr("data.ansible-forms_credentials.credentials", "credentials.0.host", "ontap1.example.com"),
+			resource.TestCheckResourceAttr("data.ansible-forms_credentials.credentials", "credentials.0.user", "admin"),
+			resource.TestCheckResourceAttr("data.ansible-forms_credentials.credentials", "credentials.0.description", "ONTAP cluster admin"),
+			resource.TestCheckResourceAttr("data.ansible-forms_credentials.credentials", "credentials.0.secure", "true"),
+			resource.TestCheckNoResourceAttr("data.ansible-forms_credentials.credentials", "credentials.0.password"))
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: server.providerConfig() + `
+data "ansible-forms_credentials" "credentials" {
+  cx_profile_name = "cluster4"
+}`,
+				Check: check,
+			},
+		},
+	})
+}
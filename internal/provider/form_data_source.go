@@ -0,0 +1,314 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/interfaces"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &FormDataSource{}
+
+// FormDataSource defines the data source implementation.
+type FormDataSource struct {
+	config resourceOrDataSourceConfig
+}
+
+// NewFormDataSource is a helper function to simplify the provider implementation.
+func NewFormDataSource() datasource.DataSource {
+	return &FormDataSource{
+		config: resourceOrDataSourceConfig{
+			name: "form",
+		},
+	}
+}
+
+// FormDataSourceModel maps the data source schema data.
+type FormDataSourceModel struct {
+	CxProfileName       types.String               `tfsdk:"cx_profile_name"`
+	Name                types.String               `tfsdk:"name"`
+	ID                  types.String               `tfsdk:"id"`
+	Description         types.String               `tfsdk:"description"`
+	Type                types.String               `tfsdk:"type"`
+	Categories          []types.String             `tfsdk:"categories"`
+	Roles               []types.String             `tfsdk:"roles"`
+	Playbook            types.String               `tfsdk:"playbook"`
+	Template            types.String               `tfsdk:"template"`
+	Approval            *FormDataSourceApproval    `tfsdk:"approval"`
+	Fields              []FormDataSourceFieldModel `tfsdk:"fields"`
+	RequiredCredentials []types.String             `tfsdk:"required_credentials"`
+}
+
+// FormDataSourceApproval maps the approval of a form.
+type FormDataSourceApproval struct {
+	Title   types.String   `tfsdk:"title"`
+	Message types.String   `tfsdk:"message"`
+	Roles   []types.String `tfsdk:"roles"`
+}
+
+// FormDataSourceFieldModel maps a form field.
+type FormDataSourceFieldModel struct {
+	Name         types.String                    `tfsdk:"name"`
+	Type         types.String                    `tfsdk:"type"`
+	Label        types.String                    `tfsdk:"label"`
+	Required     types.Bool                      `tfsdk:"required"`
+	Default      types.String                    `tfsdk:"default"`
+	Values       types.String                    `tfsdk:"values"`
+	Expression   types.String                    `tfsdk:"expression"`
+	Dependencies []FormDataSourceDependencyModel `tfsdk:"dependencies"`
+	Hide         types.Bool                      `tfsdk:"hide"`
+	NoOutput     types.Bool                      `tfsdk:"no_output"`
+	AsCredential types.Bool                      `tfsdk:"as_credential"`
+}
+
+// FormDataSourceDependencyModel maps a field dependency.
+type FormDataSourceDependencyModel struct {
+	Name   types.String `tfsdk:"name"`
+	Values types.String `tfsdk:"values"`
+}
+
+// Metadata returns the data source type name.
+func (d *FormDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + d.config.name
+}
+
+// Schema defines the schema for the data source.
+func (d *FormDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Retrieves a form, with its field definitions.",
+
+		Attributes: map[string]schema.Attribute{
+			"cx_profile_name": schema.StringAttribute{
+				MarkdownDescription: "Connection profile name",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Form name.",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the form, the form name.",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Form description.",
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Form type, ansible, awx or multistep.",
+				Computed:            true,
+			},
+			"categories": schema.ListAttribute{
+				MarkdownDescription: "Categories the form belongs to.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"roles": schema.ListAttribute{
+				MarkdownDescription: "Roles allowed to use the form.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"playbook": schema.StringAttribute{
+				MarkdownDescription: "Playbook run by ansible forms.",
+				Computed:            true,
+			},
+			"template": schema.StringAttribute{
+				MarkdownDescription: "AWX job template launched by awx forms.",
+				Computed:            true,
+			},
+			"approval": schema.SingleNestedAttribute{
+				MarkdownDescription: "Approval of the jobs launched with the form, null when jobs do not need an approval.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"title": schema.StringAttribute{
+						MarkdownDescription: "Approval title.",
+						Computed:            true,
+					},
+					"message": schema.StringAttribute{
+						MarkdownDescription: "Approval message.",
+						Computed:            true,
+					},
+					"roles": schema.ListAttribute{
+						MarkdownDescription: "Roles allowed to approve the jobs.",
+						ElementType:         types.StringType,
+						Computed:            true,
+					},
+				},
+			},
+			"fields": schema.ListNestedAttribute{
+				MarkdownDescription: "Form fields, in form order.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Field name, the extravars key.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Field type, eg text, number, enum, checkbox or expression.",
+							Computed:            true,
+						},
+						"label": schema.StringAttribute{
+							MarkdownDescription: "Field label.",
+							Computed:            true,
+						},
+						"required": schema.BoolAttribute{
+							MarkdownDescription: "Whether a value is required.",
+							Computed:            true,
+						},
+						"default": schema.StringAttribute{
+							MarkdownDescription: "Default value, JSON encoded.",
+							Computed:            true,
+						},
+						"values": schema.StringAttribute{
+							MarkdownDescription: "Allowed values of enum fields, JSON encoded.",
+							Computed:            true,
+						},
+						"expression": schema.StringAttribute{
+							MarkdownDescription: "Expression computing the values or the value of the field.",
+							Computed:            true,
+						},
+						"dependencies": schema.ListNestedAttribute{
+							MarkdownDescription: "The field is only shown when each of these fields has one of the values.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										MarkdownDescription: "Field name.",
+										Computed:            true,
+									},
+									"values": schema.StringAttribute{
+										MarkdownDescription: "Field values, JSON encoded.",
+										Computed:            true,
+									},
+								},
+							},
+						},
+						"hide": schema.BoolAttribute{
+							MarkdownDescription: "Whether the field is hidden in the form.",
+							Computed:            true,
+						},
+						"no_output": schema.BoolAttribute{
+							MarkdownDescription: "Whether the field is excluded from the extravars.",
+							Computed:            true,
+						},
+						"as_credential": schema.BoolAttribute{
+							MarkdownDescription: "Whether the field holds the name of a credential.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"required_credentials": schema.ListAttribute{
+				MarkdownDescription: "Names of the fields holding credentials, expected in the job `credentials`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *FormDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Form Data Source Configure Type",
+			fmt.Sprintf("Expected Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	}
+	d.config.providerConfig = config
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *FormDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FormDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	// we need to defer setting the client until we can read the connection profile name
+	client, err := getRestClient(errorHandler, d.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+
+	form, err := interfaces.GetFormByName(errorHandler, client, data.Name.ValueString())
+	if err != nil {
+		// error reporting done inside GetFormByName
+		return
+	}
+	if form == nil {
+		errorHandler.MakeAndReportError("form not found", fmt.Sprintf("form %s does not exist, or is not visible to the user", data.Name.ValueString()))
+		return
+	}
+
+	setFormDataSourceModel(&resp.Diagnostics, &data, form)
+
+	tflog.Debug(ctx, fmt.Sprintf("read a data source: form %s, %d fields", data.Name.ValueString(), len(data.Fields)))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setFormDataSourceModel copies the form into data.
+func setFormDataSourceModel(diags *diag.Diagnostics, data *FormDataSourceModel, form *interfaces.FormGetDataSourceModel) {
+	data.ID = types.StringValue(form.Name)
+	data.Description = types.StringValue(form.Description)
+	data.Type = types.StringValue(form.Type)
+	data.Categories = flattenTypesStringList(form.Categories)
+	data.Roles = flattenTypesStringList(form.Roles)
+	data.Playbook = types.StringValue(form.Playbook)
+	data.Template = types.StringValue(form.Template)
+	data.Approval = nil
+	if form.Approval != nil {
+		data.Approval = &FormDataSourceApproval{
+			Title:   types.StringValue(form.Approval.Title),
+			Message: types.StringValue(form.Approval.Message),
+			Roles:   flattenTypesStringList(form.Approval.Roles),
+		}
+	}
+	data.Fields = make([]FormDataSourceFieldModel, len(form.Fields))
+	for index, field := range form.Fields {
+		var dependencies []FormDataSourceDependencyModel
+		for _, dependency := range field.Dependencies {
+			dependencies = append(dependencies, FormDataSourceDependencyModel{
+				Name:   types.StringValue(dependency.Name),
+				Values: anyToJSONStringValue(diags, dependency.Values),
+			})
+		}
+		data.Fields[index] = FormDataSourceFieldModel{
+			Name:         types.StringValue(field.Name),
+			Type:         types.StringValue(field.Type),
+			Label:        types.StringValue(field.Label),
+			Required:     types.BoolValue(field.Required),
+			Default:      anyToJSONStringValue(diags, field.Default),
+			Values:       anyToJSONStringValue(diags, field.Values),
+			Expression:   types.StringValue(field.Expression),
+			Dependencies: dependencies,
+			Hide:         types.BoolValue(field.Hide),
+			NoOutput:     types.BoolValue(field.NoOutput),
+			AsCredential: types.BoolValue(field.AsCredential),
+		}
+	}
+	data.RequiredCredentials = flattenTypesStringList(form.RequiredCredentials())
+}
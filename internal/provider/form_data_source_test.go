@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"terraform-provider-ansible-forms/internal/fakeserver"
+)
+
+func TestAccFormDataSource(t *testing.T) {
+	server := newTestAccServer(t)
+	if server.fake == nil {
+		t.Skip("the form is only defined in the fake Ansible Forms server")
+	}
+	server.fake.AddForm(fakeserver.Form{
+		Name:       "Create Share",
+		Type:       "ansible",
+		Playbook:   "create_share.yaml",
+		Categories: []string{"Storage"},
+		Approval:   &fakeserver.Approval{Title: "Approve", Roles: []string{"admin"}},
+		Fields: []map[string]any{
+			{"name": "size", "type": "number", "label": "Size", "required": true, "default": 10},
+			{"name": "protocol", "type": "enum", "values": []string{"cifs", "nfs"}, "dependencies": []map[string]any{{"name": "size", "values": []int{10, 20}}}},
+			{"name": "ontap_cred", "type": "expression", "expression": "'ontap'", "hide": true, "noOutput": true, "asCredential": true},
+		},
+	})
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      server.providerConfig() + testAccFormDataSourceConfig("Non Existent Form Name"),
+				ExpectError: regexp.MustCompile("form not found"),
+			},
+			{
+				Config: server.providerConfig() + testAccFormDataSourceConfig("Create Share"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ansible-forms_form.form", "id", "Create Share"),
+					resource.TestCheckResourceAttr("data.ansible-forms_form.form", "playbook", "create_share.yaml"),
+					resource.TestCheckResourceAttr("data.ansible-forms_form.form", "approval.title", "Approve"),
+					resource.TestCheckResourceAttr("data.ansible-forms_form.form", "approval.roles.0", "admin"),
+					resource.TestCheckResourceAttr("data.ansible-forms_form.form", "fields.#", "3"),
+					resource.TestCheckResourceAttr("data.ansible-forms_form.form", "fields.0.required", "true"),
+					resource.TestCheckResourceAttr("data.ansible-forms_form.form", "fields.0.default", "10"),
+					resource.TestCheckNoResourceAttr("data.ansible-forms_form.form", "fields.0.values"),
+					resource.TestCheckResourceAttr("data.ansible-forms_form.form", "fields.1.values", `["cifs","nfs"]`),
+					resource.TestCheckResourceAttr("data.ansible-forms_form.form", "fields.1.dependencies.0.name", "size"),
+					resource.TestCheckResourceAttr("data.ansible-forms_form.form", "fields.1.dependencies.0.values", "[10,20]"),
+					resource.TestCheckResourceAttr("data.ansible-forms_form.form", "fields.2.hide", "true"),
+					resource.TestCheckResourceAttr("data.ansible-forms_form.form", "fields.2.no_output", "true"),
+					resource.TestCheckResourceAttr("data.ansible-forms_form.form", "required_credentials.#", "1"),
+					resource.TestCheckResourceAttr("data.ansible-forms_form.form", "required_credentials.0", "ontap_cred")),
+			},
+		},
+	})
+}
+
+func testAccFormDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+data "ansible-forms_form" "form" {
+  cx_profile_name = "cluster4"
+  name            = "%s"
+}`, name)
+}
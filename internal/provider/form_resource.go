@@ -0,0 +1,470 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/interfaces"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &FormResource{}
+	_ resource.ResourceWithConfigure      = &FormResource{}
+	_ resource.ResourceWithValidateConfig = &FormResource{}
+	_ resource.ResourceWithImportState    = &FormResource{}
+)
+
+// NewFormResource is a helper function to simplify the provider implementation.
+func NewFormResource() resource.Resource {
+	return &FormResource{
+		config: resourceOrDataSourceConfig{
+			name: "form",
+		},
+	}
+}
+
+// FormResource is the resource implementation.
+type FormResource struct {
+	config resourceOrDataSourceConfig
+}
+
+// FormResourceModel maps the resource schema data.
+type FormResourceModel struct {
+	CxProfileName types.String               `tfsdk:"cx_profile_name"`
+	Name          types.String               `tfsdk:"name"`
+	ID            types.String               `tfsdk:"id"`
+	Definition    types.String               `tfsdk:"definition"`
+	Description   types.String               `tfsdk:"description"`
+	Type          types.String               `tfsdk:"type"`
+	Playbook      types.String               `tfsdk:"playbook"`
+	Template      types.String               `tfsdk:"template"`
+	Categories    types.List                 `tfsdk:"categories"`
+	Roles         types.List                 `tfsdk:"roles"`
+	Approval      *FormResourceApprovalModel `tfsdk:"approval"`
+	Fields        types.String               `tfsdk:"fields"`
+}
+
+// FormResourceApprovalModel maps the approval of a form.
+type FormResourceApprovalModel struct {
+	Title   types.String   `tfsdk:"title"`
+	Message types.String   `tfsdk:"message"`
+	Roles   []types.String `tfsdk:"roles"`
+}
+
+// Metadata returns the resource type name.
+func (r *FormResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.config.name
+}
+
+// Schema defines the schema for the resource.
+func (r *FormResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a form definition in the forms configuration. " +
+			"The form is given either with attributes, or as a YAML or JSON `definition`. " +
+			"The forms configuration is read and saved as a whole, with optimistic locking so that changes to other forms are not lost: " +
+			"it is read again and compared just before it is saved, and the change is applied again to the latest configuration when it was modified in between.",
+
+		Attributes: map[string]schema.Attribute{
+			"cx_profile_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Connection profile name.",
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "Form name, unique in the forms configuration.",
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Identifier of the form, the form name.",
+			},
+			"definition": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Form definition as a YAML or JSON object, as found in `forms.yaml`, eg with `file` or `yamlencode`. " +
+					"`name` may be omitted. Conflicts with the other form attributes.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Form description.",
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Form type, ansible, awx or multistep.",
+			},
+			"playbook": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Playbook run by ansible forms.",
+			},
+			"template": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "AWX job template launched by awx forms.",
+			},
+			"categories": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Categories the form belongs to.",
+			},
+			"roles": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Roles allowed to use the form.",
+			},
+			"approval": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Approval of the jobs launched with the form.",
+				Attributes: map[string]schema.Attribute{
+					"title": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Approval title.",
+					},
+					"message": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Approval message.",
+					},
+					"roles": schema.ListAttribute{
+						Optional:            true,
+						ElementType:         types.StringType,
+						MarkdownDescription: "Roles allowed to approve the jobs.",
+					},
+				},
+			},
+			"fields": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Form fields as a JSON encoded list, eg with `jsonencode`.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *FormResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Form Resource Configure Type",
+			fmt.Sprintf("Expected Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	}
+	r.config.providerConfig = config
+}
+
+// ValidateConfig reports conflicting attributes, and definitions or fields that cannot be decoded.
+func (r *FormResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data FormResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !data.Definition.IsNull() {
+		attributes := map[string]bool{
+			"description": !data.Description.IsNull(),
+			"type":        !data.Type.IsNull(),
+			"playbook":    !data.Playbook.IsNull(),
+			"template":    !data.Template.IsNull(),
+			"categories":  !data.Categories.IsNull(),
+			"roles":       !data.Roles.IsNull(),
+			"approval":    data.Approval != nil,
+			"fields":      !data.Fields.IsNull(),
+		}
+		for attribute, set := range attributes {
+			if set {
+				resp.Diagnostics.AddAttributeError(path.Root(attribute), "conflicting form attributes",
+					fmt.Sprintf("%s cannot be set with definition, add it to the definition instead.", attribute))
+			}
+		}
+	}
+	if resp.Diagnostics.HasError() || data.Definition.IsUnknown() || data.Name.IsUnknown() || data.Fields.IsUnknown() ||
+		data.Categories.IsUnknown() || data.Roles.IsUnknown() {
+		return
+	}
+	formDefinition(ctx, &resp.Diagnostics, &data)
+}
+
+// formDefinition returns the form definition described by data, with its name.
+func formDefinition(ctx context.Context, diags *diag.Diagnostics, data *FormResourceModel) map[string]any {
+	name := data.Name.ValueString()
+	if !data.Definition.IsNull() {
+		form, err := decodeYAMLDocument(data.Definition.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("definition"), "invalid form definition", err.Error())
+			return nil
+		}
+		if value, ok := form["name"]; ok && value != name {
+			diags.AddAttributeError(path.Root("definition"), "invalid form definition",
+				fmt.Sprintf("The definition name %v does not match name %s.", value, name))
+			return nil
+		}
+		form["name"] = name
+		return form
+	}
+
+	form := map[string]any{"name": name}
+	for key, value := range map[string]types.String{"description": data.Description, "type": data.Type, "playbook": data.Playbook, "template": data.Template} {
+		if !value.IsNull() {
+			form[key] = value.ValueString()
+		}
+	}
+	for key, value := range map[string]types.List{"categories": data.Categories, "roles": data.Roles} {
+		if !value.IsNull() {
+			var values []string
+			diags.Append(value.ElementsAs(ctx, &values, false)...)
+			form[key] = stringsToAnyList(flattenTypesStringList(values))
+		}
+	}
+	if data.Approval != nil {
+		approval := map[string]any{"title": data.Approval.Title.ValueString()}
+		if !data.Approval.Message.IsNull() {
+			approval["message"] = data.Approval.Message.ValueString()
+		}
+		if data.Approval.Roles != nil {
+			approval["roles"] = stringsToAnyList(data.Approval.Roles)
+		}
+		form["approval"] = approval
+	}
+	if !data.Fields.IsNull() {
+		var fields []any
+		if err := json.Unmarshal([]byte(data.Fields.ValueString()), &fields); err != nil {
+			diags.AddAttributeError(path.Root("fields"), "invalid form fields", fmt.Sprintf("fields must be a JSON encoded list: %s", err))
+			return nil
+		}
+		form["fields"] = fields
+	}
+
+	return form
+}
+
+// stringsToAnyList returns the values of a list of strings.
+func stringsToAnyList(values []types.String) []any {
+	// flattenTypesStringList returns nil for an empty list
+	list := make([]any, len(values))
+	for index, value := range values {
+		list[index] = value.ValueString()
+	}
+	return list
+}
+
+// anyToTypesStringList returns a list of strings from a decoded JSON value, or nil when it is not a list.
+func anyToTypesStringList(value any) []types.String {
+	list, ok := value.([]any)
+	if !ok {
+		return nil
+	}
+	values := make([]types.String, len(list))
+	for index, element := range list {
+		values[index] = anyToTypesString(element)
+	}
+	return values
+}
+
+// anyToTypesListValue returns a list of strings from a decoded JSON value, null when it is not a list.
+func anyToTypesListValue(diags *diag.Diagnostics, value any) types.List {
+	if _, ok := value.([]any); !ok {
+		return types.ListNull(types.StringType)
+	}
+	list, d := types.ListValueFrom(context.Background(), types.StringType, anyToTypesStringList(value))
+	diags.Append(d...)
+	return list
+}
+
+// anyToTypesString returns a string from a decoded JSON value, null when it is not set.
+func anyToTypesString(value any) types.String {
+	switch v := value.(type) {
+	case nil:
+		return types.StringNull()
+	case string:
+		return types.StringValue(v)
+	default:
+		return types.StringValue(fmt.Sprint(v))
+	}
+}
+
+// setFormResourceModel copies the server form definition into data, keeping the configuration when it is equivalent.
+func setFormResourceModel(ctx context.Context, diags *diag.Diagnostics, data *FormResourceModel, form map[string]any) {
+	data.ID = data.Name
+	if !data.Definition.IsNull() {
+		var current map[string]any
+		if value := formDefinition(ctx, &diag.Diagnostics{}, data); value != nil {
+			current = value
+		}
+		if !reflect.DeepEqual(current, form) {
+			definition := make(map[string]any, len(form))
+			for key, value := range form {
+				if key != "name" {
+					definition[key] = value
+				}
+			}
+			data.Definition = anyToJSONStringValue(diags, definition)
+		}
+		return
+	}
+
+	data.Description = anyToTypesString(form["description"])
+	data.Type = anyToTypesString(form["type"])
+	data.Playbook = anyToTypesString(form["playbook"])
+	data.Template = anyToTypesString(form["template"])
+	data.Categories = anyToTypesListValue(diags, form["categories"])
+	data.Roles = anyToTypesListValue(diags, form["roles"])
+	data.Approval = nil
+	if approval, ok := form["approval"].(map[string]any); ok {
+		data.Approval = &FormResourceApprovalModel{
+			Title:   anyToTypesString(approval["title"]),
+			Message: anyToTypesString(approval["message"]),
+			Roles:   anyToTypesStringList(approval["roles"]),
+		}
+	}
+	var currentFields any
+	if !data.Fields.IsNull() {
+		_ = json.Unmarshal([]byte(data.Fields.ValueString()), &currentFields)
+	}
+	if !reflect.DeepEqual(currentFields, form["fields"]) {
+		data.Fields = anyToJSONStringValue(diags, form["fields"])
+	}
+}
+
+// Create a new resource.
+func (r *FormResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *FormResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	form := formDefinition(ctx, &resp.Diagnostics, data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	if err = interfaces.CreateFormDefinition(errorHandler, client, form); err != nil {
+		return
+	}
+	data.ID = data.Name
+
+	tflog.Trace(ctx, fmt.Sprintf("created a form resource: %s", data.Name.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read resource information.
+func (r *FormResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *FormResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	form, err := interfaces.GetFormDefinition(errorHandler, client, data.Name.ValueString())
+	if err != nil {
+		return
+	}
+	if form == nil {
+		resp.Diagnostics.AddWarning("form not found",
+			fmt.Sprintf("Form %s no longer exists in Ansible Forms, it is removed from the state and will be created again on the next apply.", data.Name.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	setFormResourceModel(ctx, &resp.Diagnostics, data, form)
+
+	tflog.Debug(ctx, fmt.Sprintf("read a form resource: %s", data.Name.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update replaces the form definition.
+func (r *FormResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *FormResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	form := formDefinition(ctx, &resp.Diagnostics, data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	if err = interfaces.UpdateFormDefinition(errorHandler, client, form); err != nil {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the form from the forms configuration.
+func (r *FormResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *FormResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	_ = interfaces.DeleteFormDefinition(errorHandler, client, data.Name.ValueString())
+}
+
+// ImportState imports a form with an identifier in the format name,cx_profile_name.
+// The form attributes are imported, not definition.
+func (r *FormResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Debug(ctx, fmt.Sprintf("import req a form resource: %#v", req))
+	// form names may contain commas, the connection profile name is after the last one
+	index := strings.LastIndex(req.ID, ",")
+	if index <= 0 || index == len(req.ID)-1 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: name,cx_profile_name. Got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID[:index])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cx_profile_name"), req.ID[index+1:])...)
+}
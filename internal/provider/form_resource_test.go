@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"terraform-provider-ansible-forms/internal/fakeserver"
+)
+
+func TestAccFormResource(t *testing.T) {
+	server := newTestAccServer(t)
+	steps := []resource.TestStep{
+		{
+			Config:      server.providerConfig() + testAccFormResourceConfig("Creates a share", `{ name = "share_name", type = "text", required = true }`) + testAccFormResourceDefinitionConfig(`description: Conflict`, `type = "ansible"`),
+			ExpectError: regexp.MustCompile("conflicting form attributes"),
+		},
+		{
+			Config: server.providerConfig() + testAccFormResourceConfig("Creates a share", `{ name = "share_name", type = "text", required = true }`),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("ansible-forms_form.form", "id", "TF Create Share"),
+				resource.TestCheckResourceAttr("ansible-forms_form.form", "description", "Creates a share"),
+				resource.TestCheckResourceAttr("ansible-forms_form.form", "categories.0", "Storage"),
+				resource.TestCheckResourceAttr("ansible-forms_form.form", "approval.title", "Approve the share"),
+				testAccCheckFakeFormDefinition(server, "TF Create Share", "description", "Creates a share")),
+		},
+		{
+			ResourceName:      "ansible-forms_form.form",
+			ImportState:       true,
+			ImportStateId:     "TF Create Share,cluster4",
+			ImportStateVerify: true,
+		},
+		{
+			Config: server.providerConfig() + testAccFormResourceConfig("Creates a CIFS share", `{ name = "share_name", type = "text", required = true }, { name = "size", type = "number", default = 10 }`),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("ansible-forms_form.form", "description", "Creates a CIFS share"),
+				testAccCheckFakeFormDefinition(server, "TF Create Share", "description", "Creates a CIFS share")),
+		},
+		{
+			Config: server.providerConfig() + testAccFormResourceDefinitionConfig("description: Deletes a share\ntype: ansible\nplaybook: delete_share.yaml\nfields:\n  - name: share_name\n    type: text\n    required: true", ""),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("ansible-forms_form.definition", "id", "TF Delete Share"),
+				testAccCheckFakeFormDefinition(server, "TF Delete Share", "playbook", "delete_share.yaml")),
+		},
+	}
+	var checkDestroy resource.TestCheckFunc
+	if server.fake != nil {
+		steps = append(steps, resource.TestStep{
+			// the form is changed outside of Terraform, the change is reverted
+			PreConfig: func() {
+				server.fake.AddForm(fakeserver.Form{Name: "TF Delete Share", Description: "Changed", Type: "ansible", Playbook: "delete_share.yaml"})
+			},
+			Config: server.providerConfig() + testAccFormResourceDefinitionConfig("description: Deletes a share\ntype: ansible\nplaybook: delete_share.yaml\nfields:\n  - name: share_name\n    type: text\n    required: true", ""),
+			Check:  testAccCheckFakeFormDefinition(server, "TF Delete Share", "description", "Deletes a share"),
+		})
+		checkDestroy = func(_ *terraform.State) error {
+			for _, name := range []string{"TF Create Share", "TF Delete Share"} {
+				if _, ok := server.fake.FormDefinition(name); ok {
+					return fmt.Errorf("form %s still exists", name)
+				}
+			}
+			if _, ok := server.fake.FormDefinition("Demo Form"); !ok {
+				return fmt.Errorf("form Demo Form was deleted")
+			}
+			return nil
+		}
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             checkDestroy,
+		Steps:                    steps,
+	})
+}
+
+// testAccCheckFakeFormDefinition checks a key of a form definition in the fake Ansible Forms server.
+func testAccCheckFakeFormDefinition(server testAccServer, name string, key string, want string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if server.fake == nil {
+			return nil
+		}
+		form, ok := server.fake.FormDefinition(name)
+		if !ok {
+			return fmt.Errorf("form %s not found", name)
+		}
+		if form[key] != want {
+			return fmt.Errorf("form %s %s = %v, want %s", name, key, form[key], want)
+		}
+		return nil
+	}
+}
+
+func testAccFormResourceConfig(description string, fields string) string {
+	return fmt.Sprintf(`
+resource "ansible-forms_form" "form" {
+  cx_profile_name = "cluster4"
+  name            = "TF Create Share"
+  description     = "%s"
+  type            = "ansible"
+  playbook        = "create_share.yaml"
+  categories      = ["Storage"]
+  roles           = ["admin"]
+  approval = {
+    title = "Approve the share"
+    roles = ["admin"]
+  }
+  fields = jsonencode([%s])
+}`, description, fields)
+}
+
+func testAccFormResourceDefinitionConfig(definition string, attributes string) string {
+	return fmt.Sprintf(`
+resource "ansible-forms_form" "definition" {
+  cx_profile_name = "cluster4"
+  name            = "TF Delete Share"
+  definition      = <<-EOT
+%s
+EOT
+  %s
+}`, definition, attributes)
+}
@@ -0,0 +1,291 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/interfaces"
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &FormsConfigResource{}
+	_ resource.ResourceWithConfigure   = &FormsConfigResource{}
+	_ resource.ResourceWithModifyPlan  = &FormsConfigResource{}
+	_ resource.ResourceWithImportState = &FormsConfigResource{}
+)
+
+// NewFormsConfigResource is a helper function to simplify the provider implementation.
+func NewFormsConfigResource() resource.Resource {
+	return &FormsConfigResource{
+		config: resourceOrDataSourceConfig{
+			name: "forms_config",
+		},
+	}
+}
+
+// FormsConfigResource is the resource implementation.
+type FormsConfigResource struct {
+	config resourceOrDataSourceConfig
+}
+
+// FormsConfigResourceModel maps the resource schema data.
+type FormsConfigResourceModel struct {
+	CxProfileName types.String      `tfsdk:"cx_profile_name"`
+	ID            types.String      `tfsdk:"id"`
+	Config        yamlDocumentValue `tfsdk:"config"`
+	BackupName    types.String      `tfsdk:"backup_name"`
+	Version       types.String      `tfsdk:"version"`
+}
+
+// Metadata returns the resource type name.
+func (r *FormsConfigResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.config.name
+}
+
+// Schema defines the schema for the resource.
+func (r *FormsConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages the complete forms configuration, as found in `forms.yaml`. " +
+			"The configuration is validated by the server when planning, and a server side backup is taken before it is applied.",
+
+		Attributes: map[string]schema.Attribute{
+			"cx_profile_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Connection profile name.",
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Identifier of the configuration, the connection profile name.",
+			},
+			"config": schema.StringAttribute{
+				Required:   true,
+				CustomType: yamlDocumentType{},
+				MarkdownDescription: "Complete forms configuration as a YAML or JSON object, eg with `file`. " +
+					"Formatting and key order changes are only saved in the state. Changes made outside of Terraform are shown as normalized YAML.",
+			},
+			"backup_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the server side backup taken before the configuration was last applied, to roll back.",
+			},
+			"version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA-256 hash of the configuration saved on the server.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *FormsConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Forms Config Resource Configure Type",
+			fmt.Sprintf("Expected Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	}
+	r.config.providerConfig = config
+}
+
+// ModifyPlan validates a new or changed configuration with the server.
+func (r *FormsConfigResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan, state *FormsConfigResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() || plan.Config.IsUnknown() || plan.CxProfileName.IsUnknown() {
+		return
+	}
+	// a formatting change is only saved in the state, the configuration is not applied again
+	if state != nil && plan.Config.semanticallyEqual(ctx, state.Config) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("backup_name"), state.BackupName)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("version"), state.Version)...)
+		return
+	}
+
+	config, err := decodeYAMLDocument(plan.Config.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("config"), "invalid forms configuration", err.Error())
+		return
+	}
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, plan.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	_ = interfaces.ValidateFormsConfig(errorHandler, client, config)
+}
+
+// apply validates and saves the configuration, after a server side backup.
+func (r *FormsConfigResource) apply(errorHandler *utils.ErrorHandler, data *FormsConfigResourceModel) {
+	config, err := decodeYAMLDocument(data.Config.ValueString())
+	if err != nil {
+		errorHandler.MakeAndReportError("invalid forms configuration", err.Error())
+		return
+	}
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	if err = interfaces.ValidateFormsConfig(errorHandler, client, config); err != nil {
+		return
+	}
+	backupName, err := interfaces.BackupFormsConfig(errorHandler, client)
+	if err != nil {
+		return
+	}
+	if err = interfaces.ReplaceFormsConfig(errorHandler, client, config); err != nil {
+		return
+	}
+	data.ID = data.CxProfileName
+	data.BackupName = types.StringValue(backupName)
+	data.Version = r.version(errorHandler, client)
+}
+
+// version returns the hash of the configuration saved on the server.
+func (r *FormsConfigResource) version(errorHandler *utils.ErrorHandler, client restclient.Client) types.String {
+	config, err := interfaces.GetFormsConfig(errorHandler, client)
+	if err != nil {
+		return types.StringUnknown()
+	}
+	version, err := interfaces.FormsConfigHash(config)
+	if err != nil {
+		errorHandler.MakeAndReportError("error hashing forms configuration", err.Error())
+		return types.StringUnknown()
+	}
+	return types.StringValue(version)
+}
+
+// Create saves the configuration.
+func (r *FormsConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *FormsConfigResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(utils.NewErrorHandler(ctx, &resp.Diagnostics), data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("created a forms config resource, backup %s", data.BackupName.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read resource information.
+func (r *FormsConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *FormsConfigResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	config, err := interfaces.GetFormsConfig(errorHandler, client)
+	if err != nil {
+		return
+	}
+	version, err := interfaces.FormsConfigHash(config)
+	if err != nil {
+		errorHandler.MakeAndReportError("error hashing forms configuration", err.Error())
+		return
+	}
+	// the configuration is kept as written when it is unchanged on the server
+	if version != data.Version.ValueString() {
+		normalized, err := normalizeYAMLDocument(config)
+		if err != nil {
+			errorHandler.MakeAndReportError("error encoding forms configuration", err.Error())
+			return
+		}
+		data.Config = newYAMLDocumentValue(normalized)
+	}
+	data.ID = data.CxProfileName
+	data.Version = types.StringValue(version)
+
+	tflog.Debug(ctx, fmt.Sprintf("read a forms config resource, version %s", version))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update saves the configuration.
+func (r *FormsConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *FormsConfigResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// only the connection profile or the formatting changed
+	if data.Config.semanticallyEqual(ctx, state.Config) {
+		data.BackupName = state.BackupName
+		data.Version = state.Version
+		data.ID = data.CxProfileName
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	r.apply(utils.NewErrorHandler(ctx, &resp.Diagnostics), data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("updated a forms config resource, backup %s", data.BackupName.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the resource from the state, the forms configuration is left unchanged.
+func (r *FormsConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *FormsConfigResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("forms config resource removed from the state, the configuration is left unchanged, last backup %s", data.BackupName.ValueString()))
+}
+
+// ImportState imports the forms configuration of the connection profile given as identifier.
+func (r *FormsConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", "Expected the connection profile name as import identifier.")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cx_profile_name"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"terraform-provider-ansible-forms/internal/fakeserver"
+)
+
+func TestAccFormsConfigResource(t *testing.T) {
+	server := newTestAccServer(t)
+	if server.fake == nil {
+		t.Skip("the forms configuration of a real server is not replaced by acceptance tests")
+	}
+	config := `categories:
+  - name: Storage
+    icon: database
+forms:
+  - name: Create Share
+    type: ansible
+    playbook: create_share.yaml
+    categories: [Storage]
+  - name: Delete Share
+    type: ansible
+    playbook: delete_share.yaml
+`
+	// the same configuration, as JSON with another key order
+	reformatted := `{"forms": [{"playbook": "create_share.yaml", "name": "Create Share", "type": "ansible", "categories": ["Storage"]},
+  {"name": "Delete Share", "type": "ansible", "playbook": "delete_share.yaml"}],
+  "categories": [{"icon": "database", "name": "Storage"}]}`
+	var backupName string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      server.providerConfig() + testAccFormsConfigResourceConfig("forms:\n  - name: Twice\n  - name: Twice"),
+				ExpectError: regexp.MustCompile(`forms must be objects with a\s+unique name`),
+			},
+			{
+				Config: server.providerConfig() + testAccFormsConfigResourceConfig(config),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ansible-forms_forms_config.config", "id", "cluster4"),
+					resource.TestCheckResourceAttrSet("ansible-forms_forms_config.config", "version"),
+					func(state *terraform.State) error {
+						backupName = state.RootModule().Resources["ansible-forms_forms_config.config"].Primary.Attributes["backup_name"]
+						// the backup holds the configuration before it was replaced
+						backup, ok := server.fake.Backup(backupName)
+						if !ok {
+							return fmt.Errorf("backup %q not found", backupName)
+						}
+						if forms := backup["forms"].([]map[string]any); len(forms) != 1 || forms[0]["name"] != "Demo Form" {
+							return fmt.Errorf("backup %s forms = %v, want Demo Form", backupName, forms)
+						}
+						if _, ok := server.fake.FormDefinition("Demo Form"); ok {
+							return fmt.Errorf("form Demo Form still exists")
+						}
+						if _, ok := server.fake.FormDefinition("Delete Share"); !ok {
+							return fmt.Errorf("form Delete Share not found")
+						}
+						return nil
+					}),
+			},
+			{
+				// formatting changes are not applied again
+				Config: server.providerConfig() + testAccFormsConfigResourceConfig(reformatted),
+				Check: func(state *terraform.State) error {
+					if name := state.RootModule().Resources["ansible-forms_forms_config.config"].Primary.Attributes["backup_name"]; name != backupName {
+						return fmt.Errorf("backup_name = %s, want %s", name, backupName)
+					}
+					return nil
+				},
+			},
+			{
+				ResourceName:            "ansible-forms_forms_config.config",
+				ImportState:             true,
+				ImportStateId:           "cluster4",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"config", "backup_name"},
+			},
+			{
+				// the form is changed outside of Terraform, the change is reverted after a new backup
+				PreConfig: func() {
+					server.fake.AddForm(fakeserver.Form{Name: "Create Share", Type: "ansible", Playbook: "changed.yaml"})
+				},
+				Config: server.providerConfig() + testAccFormsConfigResourceConfig(reformatted),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFakeFormDefinition(server, "Create Share", "playbook", "create_share.yaml"),
+					func(state *terraform.State) error {
+						if name := state.RootModule().Resources["ansible-forms_forms_config.config"].Primary.Attributes["backup_name"]; name == backupName {
+							return fmt.Errorf("backup_name = %s, want a new backup", name)
+						}
+						return nil
+					}),
+			},
+		},
+	})
+}
+
+func testAccFormsConfigResourceConfig(config string) string {
+	return fmt.Sprintf(`
+resource "ansible-forms_forms_config" "config" {
+  cx_profile_name = "cluster4"
+  config          = <<-EOT
+%s
+EOT
+}`, config)
+}
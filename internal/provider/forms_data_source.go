@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/interfaces"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &FormsDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &FormsDataSource{}
+
+// FormsDataSource defines the data source implementation.
+type FormsDataSource struct {
+	config resourceOrDataSourceConfig
+}
+
+// NewFormsDataSource is a helper function to simplify the provider implementation.
+func NewFormsDataSource() datasource.DataSource {
+	return &FormsDataSource{
+		config: resourceOrDataSourceConfig{
+			name: "forms",
+		},
+	}
+}
+
+// FormsDataSourceModel maps the data source schema data.
+type FormsDataSourceModel struct {
+	CxProfileName types.String               `tfsdk:"cx_profile_name"`
+	ID            types.String               `tfsdk:"id"`
+	Category      types.String               `tfsdk:"category"`
+	NameRegex     types.String               `tfsdk:"name_regex"`
+	Forms         []FormsDataSourceFormModel `tfsdk:"forms"`
+}
+
+// FormsDataSourceFormModel maps a form in the forms list.
+type FormsDataSourceFormModel struct {
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	Type        types.String   `tfsdk:"type"`
+	Categories  []types.String `tfsdk:"categories"`
+	Roles       []types.String `tfsdk:"roles"`
+	Image       types.String   `tfsdk:"image"`
+	Help        types.String   `tfsdk:"help"`
+}
+
+// Metadata returns the data source type name.
+func (d *FormsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + d.config.name
+}
+
+// Schema defines the schema for the data source.
+func (d *FormsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the forms visible to the user of the connection profile.",
+
+		Attributes: map[string]schema.Attribute{
+			"cx_profile_name": schema.StringAttribute{
+				MarkdownDescription: "Connection profile name",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the list, the connection profile name.",
+				Computed:            true,
+			},
+			"category": schema.StringAttribute{
+				MarkdownDescription: "Only list the forms in this category.",
+				Optional:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only list the forms with a name matching this regular expression, using the Go syntax.",
+				Optional:            true,
+			},
+			"forms": schema.ListNestedAttribute{
+				MarkdownDescription: "Forms, in configuration order.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Form name.",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Form description.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Form type, ansible, awx or multistep.",
+							Computed:            true,
+						},
+						"categories": schema.ListAttribute{
+							MarkdownDescription: "Categories the form belongs to.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"roles": schema.ListAttribute{
+							MarkdownDescription: "Roles allowed to use the form.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"image": schema.StringAttribute{
+							MarkdownDescription: "Tile image of the form.",
+							Computed:            true,
+						},
+						"help": schema.StringAttribute{
+							MarkdownDescription: "Help text of the form.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *FormsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Forms Data Source Configure Type",
+			fmt.Sprintf("Expected Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	}
+	d.config.providerConfig = config
+}
+
+// ValidateConfig reports an invalid name_regex at plan time.
+func (d *FormsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var nameRegex types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name_regex"), &nameRegex)...)
+	if resp.Diagnostics.HasError() || nameRegex.IsNull() || nameRegex.IsUnknown() {
+		return
+	}
+	if _, err := regexp.Compile(nameRegex.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "invalid name_regex", err.Error())
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *FormsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FormsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	filter := interfaces.FormsFilter{Category: data.Category.ValueString()}
+	if !data.NameRegex.IsNull() {
+		nameRegex, err := regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "invalid name_regex", err.Error())
+			return
+		}
+		filter.Name = nameRegex
+	}
+
+	// we need to defer setting the client until we can read the connection profile name
+	client, err := getRestClient(errorHandler, d.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+
+	forms, err := interfaces.GetForms(errorHandler, client, filter)
+	if err != nil {
+		// error reporting done inside GetForms
+		return
+	}
+
+	data.ID = data.CxProfileName
+	data.Forms = make([]FormsDataSourceFormModel, len(forms))
+	for index, form := range forms {
+		data.Forms[index] = FormsDataSourceFormModel{
+			Name:        types.StringValue(form.Name),
+			Description: types.StringValue(form.Description),
+			Type:        types.StringValue(form.Type),
+			Categories:  flattenTypesStringList(form.Categories),
+			Roles:       flattenTypesStringList(form.Roles),
+			Image:       types.StringValue(form.Image),
+			Help:        types.StringValue(form.Help),
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("read a data source: %d forms", len(data.Forms)))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"terraform-provider-ansible-forms/internal/fakeserver"
+)
+
+func TestAccFormsDataSource(t *testing.T) {
+	server := newTestAccServer(t)
+	check := resource.TestCheckResourceAttrSet("data.ansible-forms_forms.forms", "forms.#")
+	if server.fake != nil {
+		server.fake.AddForm(fakeserver.Form{Name: "Create Share", Description: "Creates a share", Type: "awx", Categories: []string{"Storage"}, Roles: []string{"admin"}, Image: "share.png", Help: "Creates a CIFS share"})
+		server.fake.AddForm(fakeserver.Form{Name: "Delete Share", Type: "multistep", Categories: []string{"Storage"}})
+		check = resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr("data.ansible-forms_forms.forms", "forms.#", "1"),
+			resource.TestCheckResourceAttr("data.ansible-forms_forms.forms", "forms.0.name", "Create Share"),
+			resource.TestCheckResourceAttr("data.ansible-forms_forms.forms", "forms.0.description", "Creates a share"),
+			resource.TestCheckResourceAttr("data.ansible-forms_forms.forms", "forms.0.type", "awx"),
+			resource.TestCheckResourceAttr("data.ansible-forms_forms.forms", "forms.0.categories.0", "Storage"),
+			resource.TestCheckResourceAttr("data.ansible-forms_forms.forms", "forms.0.roles.0", "admin"),
+			resource.TestCheckResourceAttr("data.ansible-forms_forms.forms", "forms.0.image", "share.png"),
+			resource.TestCheckResourceAttr("data.ansible-forms_forms.forms", "forms.0.help", "Creates a CIFS share"))
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      server.providerConfig() + testAccFormsDataSourceConfig("Storage", "(Create"),
+				ExpectError: regexp.MustCompile("invalid name_regex"),
+			},
+			{
+				Config: server.providerConfig() + testAccFormsDataSourceConfig("Storage", "^Create"),
+				Check:  check,
+			},
+		},
+	})
+}
+
+func testAccFormsDataSourceConfig(category string, nameRegex string) string {
+	return fmt.Sprintf(`
+data "ansible-forms_forms" "forms" {
+  cx_profile_name = "cluster4"
+  category        = "%s"
+  name_regex      = "%s"
+}`, category, nameRegex)
+}
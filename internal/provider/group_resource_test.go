@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccGroupResource(t *testing.T) {
+	server := newTestAccServer(t)
+	steps := []resource.TestStep{
+		{
+			Config: server.providerConfig() + testAccGroupResourceConfig("tf_storage"),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("ansible-forms_group.group", "id"),
+				resource.TestCheckResourceAttr("ansible-forms_group.group", "name", "tf_storage")),
+		},
+		{
+			ResourceName:      "ansible-forms_group.group",
+			ImportState:       true,
+			ImportStateId:     "tf_storage,cluster4",
+			ImportStateVerify: true,
+		},
+		{
+			Config: server.providerConfig() + testAccGroupResourceConfig("tf_storage_team"),
+			Check:  resource.TestCheckResourceAttr("ansible-forms_group.group", "name", "tf_storage_team"),
+		},
+	}
+	var checkDestroy resource.TestCheckFunc
+	if server.fake != nil {
+		steps = append(steps, resource.TestStep{
+			// the group is renamed outside of Terraform, the change is reverted
+			PreConfig: func() {
+				group, _ := server.fake.GroupByName("tf_storage_team")
+				group.Name = "changed"
+				server.fake.ReplaceGroup(group)
+			},
+			Config: server.providerConfig() + testAccGroupResourceConfig("tf_storage_team"),
+			Check: func(_ *terraform.State) error {
+				if _, ok := server.fake.GroupByName("tf_storage_team"); !ok {
+					return fmt.Errorf("group tf_storage_team not found")
+				}
+				return nil
+			},
+		})
+		checkDestroy = func(_ *terraform.State) error {
+			if _, ok := server.fake.GroupByName("tf_storage_team"); ok {
+				return fmt.Errorf("group tf_storage_team still exists")
+			}
+			return nil
+		}
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             checkDestroy,
+		Steps:                    steps,
+	})
+}
+
+func testAccGroupResourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "ansible-forms_group" "group" {
+  cx_profile_name = "cluster4"
+  name            = "%s"
+}`, name)
+}
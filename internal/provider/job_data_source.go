@@ -81,14 +81,16 @@ func (d *JobDataSource) Schema(ctx context.Context, req datasource.SchemaRequest
 				Computed:            true,
 			},
 			"extravars": schema.MapAttribute{
-				MarkdownDescription: "",
+				MarkdownDescription: "Extravars of the job, including the values sent as `sensitive_extravars`.",
 				ElementType:         types.StringType,
 				Computed:            true,
+				Sensitive:           true,
 			},
 			"credentials": schema.MapAttribute{
 				MarkdownDescription: "",
 				ElementType:         types.StringType,
 				Computed:            true,
+				Sensitive:           true,
 			},
 			"target": schema.StringAttribute{
 				Computed: true,
@@ -160,9 +162,13 @@ func (d *JobDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		return
 	}
 
-	restInfo, err := interfaces.GetJobByID(errorHandler, *client, data.ID.String())
+	restInfo, err := interfaces.GetJobByID(errorHandler, client, data.ID.String())
 	if err != nil {
-		// error reporting done inside GetSVMPeer
+		// error reporting done inside GetJobByID
+		return
+	}
+	if restInfo == nil {
+		errorHandler.MakeAndReportError("job not found", fmt.Sprintf("job %s does not exist", data.ID.String()))
 		return
 	}
 
@@ -181,7 +187,7 @@ func (d *JobDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
-	tflog.Debug(ctx, fmt.Sprintf("read a data source: %#v", data))
+	tflog.Debug(ctx, fmt.Sprintf("read a data source: %d, status: %s", data.ID.ValueInt64(), data.Status.ValueString()))
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
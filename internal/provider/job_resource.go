@@ -2,13 +2,23 @@ package provider
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -18,11 +28,12 @@ import (
 	"terraform-provider-ansible-forms/internal/utils"
 )
 
-// Ensure the implementation satisfies the expected interfaces.
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &JobResource{}
-	_ resource.ResourceWithConfigure = &JobResource{}
+	_ resource.Resource                = &JobResource{}
+	_ resource.ResourceWithConfigure   = &JobResource{}
+	_ resource.ResourceWithModifyPlan  = &JobResource{}
+	_ resource.ResourceWithImportState = &JobResource{}
 )
 
 // NewJobResource is a helper function to simplify the provider implementation.
@@ -41,20 +52,24 @@ type JobResource struct {
 
 // JobResourceModel maps the resource schema data.
 type JobResourceModel struct {
-	CxProfileName types.String `tfsdk:"cx_profile_name"`
-	ID            types.String `tfsdk:"id"`
-	LastUpdated   types.String `tfsdk:"last_updated"`
-	FormName      types.String `tfsdk:"form_name"`
-	Status        types.String `tfsdk:"status"`
-	Extravars     types.Map    `tfsdk:"extravars"`
-	Credentials   types.Map    `tfsdk:"credentials"`
-	Target        types.String `tfsdk:"target"`
-	Output        types.String `tfsdk:"output"`
-	Counter       types.Int64  `tfsdk:"counter"`
-	NoOfRecords   types.Int64  `tfsdk:"no_of_records"`
-	Start         types.String `tfsdk:"start"`
-	End           types.String `tfsdk:"end"`
-	Approval      types.String `tfsdk:"approval"`
+	CxProfileName          types.String `tfsdk:"cx_profile_name"`
+	ID                     types.String `tfsdk:"id"`
+	LastUpdated            types.String `tfsdk:"last_updated"`
+	FormName               types.String `tfsdk:"form_name"`
+	Status                 types.String `tfsdk:"status"`
+	Extravars              types.Map    `tfsdk:"extravars"`
+	SensitiveExtravars     types.Map    `tfsdk:"sensitive_extravars"`
+	SensitiveExtravarsHash types.String `tfsdk:"sensitive_extravars_hash"`
+	Credentials            types.Map    `tfsdk:"credentials"`
+	SkipFormValidation     types.Bool   `tfsdk:"skip_form_validation"`
+	SkipCredentialCheck    types.Bool   `tfsdk:"skip_credential_check"`
+	Target                 types.String `tfsdk:"target"`
+	Output                 types.String `tfsdk:"output"`
+	Counter                types.Int64  `tfsdk:"counter"`
+	NoOfRecords            types.Int64  `tfsdk:"no_of_records"`
+	Start                  types.String `tfsdk:"start"`
+	End                    types.String `tfsdk:"end"`
+	Approval               types.String `tfsdk:"approval"`
 }
 
 // JobResourceModelCredentials ...
@@ -80,19 +95,56 @@ func (r *JobResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				MarkdownDescription: "Connection profile name.",
 			},
 			"form_name": schema.StringAttribute{
-				Required:            true,
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 				MarkdownDescription: "Form name of a job.",
 			},
 			"extravars": schema.MapAttribute{
-				Required:            true,
-				ElementType:         types.StringType,
-				MarkdownDescription: "Extra vars of a job.",
+				Required:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					// extravars is null after an import, the configuration is then compared with the job in ModifyPlan
+					mapplanmodifier.RequiresReplaceIf(func(_ context.Context, req planmodifier.MapRequest, resp *mapplanmodifier.RequiresReplaceIfFuncResponse) {
+						resp.RequiresReplace = !req.StateValue.IsNull()
+					}, "Launch a new job when extravars changes.", "Launch a new job when `extravars` changes."),
+				},
+				MarkdownDescription: "Extra vars of a job. They are not imported, as they cannot be told apart from `sensitive_extravars` in the job.",
+			},
+			"sensitive_extravars": schema.MapAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				ElementType: types.StringType,
+				MarkdownDescription: "Extra vars of a job that are merged into `extravars` when the job is launched, and take precedence over them. " +
+					"They are never stored in the state, only `sensitive_extravars_hash` is. Requires Terraform 1.11 or later.",
+			},
+			"sensitive_extravars_hash": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "HMAC-SHA256 of `sensitive_extravars`, keyed with a random salt stored with it, used to detect changes. " +
+					"The salt prevents precomputed lookups, but a short or guessable secret can still be brute forced by anyone who can read the state.",
 			},
 			"credentials": schema.MapAttribute{
-				Required:            true,
-				ElementType:         types.StringType,
+				Required:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
 				MarkdownDescription: "Credentials of a job.",
 			},
+			"skip_form_validation": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Do not check `extravars` and `sensitive_extravars` against the form definition when planning a new job. " +
+					"By default, missing required fields, unknown fields, values that are not allowed and values that do not match the field regular expression are reported. " +
+					"A form that does not exist yet, eg created in the same apply with `ansible-forms_form`, is only reported as a warning.",
+			},
+			"skip_credential_check": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Do not check that every value of `credentials` names an existing credential when planning a new job. " +
+					"A credential that does not exist yet, eg created in the same apply with `ansible-forms_credential`, is only reported as a warning.",
+			},
 			"id": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
@@ -186,7 +238,6 @@ func (r *JobResource) Configure(_ context.Context, req resource.ConfigureRequest
 // Create a new resource.
 func (r *JobResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data *JobResourceModel
-	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -195,9 +246,34 @@ func (r *JobResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	// sensitive_extravars is write-only, so it is only present in the configuration
+	var sensitiveExtravars types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sensitive_extravars"), &sensitiveExtravars)...)
+	var extravars, secretExtravars, credentials map[string]string
+	resp.Diagnostics.Append(data.Extravars.ElementsAs(ctx, &extravars, false)...)
+	resp.Diagnostics.Append(sensitiveExtravars.ElementsAs(ctx, &secretExtravars, false)...)
+	resp.Diagnostics.Append(data.Credentials.ElementsAs(ctx, &credentials, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the sensitive values are sent under arbitrary keys, and returned in the extravars of the job,
+	// they are masked wherever they appear in the logs and cassettes of this operation
+	secrets := make([]string, 0, len(secretExtravars))
+	for _, value := range secretExtravars {
+		secrets = append(secrets, value)
+	}
+	ctx = utils.ContextWithSecrets(ctx, secrets...)
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	data.SensitiveExtravarsHash = newSensitiveExtravarsHash(ctx, sensitiveExtravars, &resp.Diagnostics)
+
 	var request interfaces.JobResourceModel
 	request.Form = data.FormName.ValueString()
-	//request.Extravars = data.Extravars.Elements()
+	request.Extravars = mergeExtravars(extravars, secretExtravars)
+	request.Credentials = make(map[string]any, len(credentials))
+	for key, value := range credentials {
+		request.Credentials[key] = value
+	}
 
 	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
 	if err != nil {
@@ -205,29 +281,54 @@ func (r *JobResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
-	job, err := interfaces.CreateJob(errorHandler, *client, request)
+	job, err := interfaces.CreateJob(errorHandler, client, request)
 	if err != nil {
 		tflog.Debug(ctx, "err creating a resource", map[string]interface{}{"err": err})
 		return
 	}
 
-	data.ID = types.StringValue(strconv.FormatInt(job.Data.ID, 10))
-	data.Status = types.StringValue(job.Data.Status)
+	data.ID = types.StringValue(strconv.FormatInt(job.ID, 10))
 	data.LastUpdated = types.StringValue(time.Now().UTC().Format(time.RFC3339))
-	data.Target = types.StringValue(job.Data.Target)
-	data.Output = types.StringValue(job.Data.Output)
-	data.Counter = types.Int64Value(job.Data.Counter)
-	data.NoOfRecords = types.Int64Value(job.Data.NoOfRecords)
-	data.Start = types.StringValue(job.Data.Start)
-	data.End = types.StringValue(job.Data.End)
-	data.Approval = types.StringValue(job.Data.Approval)
+	setJobResourceModel(data, job)
 
-	tflog.Debug(ctx, "JOB ID", map[string]interface{}{"ID": job.Data.ID, "DATA": data})
+	// the job is saved in the state even if it does not succeed, so that it is deleted on destroy
+	finalJob, err := interfaces.WaitForJob(errorHandler, client, data.ID.ValueString())
+	if finalJob != nil {
+		setJobResourceModel(data, finalJob)
+	}
+	if err != nil {
+		tflog.Debug(ctx, "job did not succeed", map[string]interface{}{"err": err})
+	}
+	tflog.Debug(ctx, "JOB ID", map[string]interface{}{"ID": data.ID.ValueString(), "status": data.Status.ValueString()})
 
 	tflog.Trace(ctx, "created a resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// mergeExtravars returns the extravars of a new job, values of sensitiveExtravars take precedence over extravars.
+func mergeExtravars(extravars map[string]string, sensitiveExtravars map[string]string) map[string]any {
+	merged := make(map[string]any, len(extravars)+len(sensitiveExtravars))
+	for key, value := range extravars {
+		merged[key] = value
+	}
+	for key, value := range sensitiveExtravars {
+		merged[key] = value
+	}
+	return merged
+}
+
+// setJobResourceModel copies the job info into the resource model.
+func setJobResourceModel(data *JobResourceModel, job *interfaces.JobGetDataSourceModel) {
+	data.Status = types.StringValue(job.Status)
+	data.Target = types.StringValue(job.Target)
+	data.Output = types.StringValue(job.Output)
+	data.Counter = types.Int64Value(job.Counter)
+	data.NoOfRecords = types.Int64Value(job.NoOfRecords)
+	data.Start = types.StringValue(job.Start)
+	data.End = types.StringValue(job.End)
+	data.Approval = types.StringValue(job.Approval)
+}
+
 // Read resource information.
 func (r *JobResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data *JobResourceModel
@@ -245,11 +346,11 @@ func (r *JobResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		// error reporting done inside NewClient
 		return
 	}
-	tflog.Debug(ctx, fmt.Sprintf("read a job resource: %#v", data))
+	tflog.Debug(ctx, fmt.Sprintf("read a job resource: %s", data.ID.ValueString()))
 
 	var job *interfaces.JobGetDataSourceModel
 	if data.ID.ValueString() != "" {
-		job, err = interfaces.GetJobByID(errorHandler, *client, data.ID.ValueString())
+		job, err = interfaces.GetJobByID(errorHandler, client, data.ID.ValueString())
 	} else {
 		return
 	}
@@ -258,6 +359,9 @@ func (r *JobResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	}
 
 	if job == nil {
+		resp.Diagnostics.AddWarning("job not found",
+			fmt.Sprintf("Job %s no longer exists in Ansible Forms, it is removed from the state and will be created again on the next apply.", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
@@ -266,43 +370,304 @@ func (r *JobResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	if job.Form != "" {
 		data.FormName = types.StringValue(job.Form)
 	}
-	if job.Status != "" {
-		data.Status = types.StringValue(job.Status)
+	setJobResourceModel(data, job)
+	// the job inputs are only read after an import, the configuration is kept otherwise.
+	// extravars is left null, as the extravars of the job include sensitive_extravars: the configuration is compared
+	// with the job on the next plan, see ModifyPlan.
+	if data.Credentials.IsNull() && job.Credentials != "" {
+		data.Credentials = jsonStringToMapValue(ctx, &resp.Diagnostics, job.Credentials)
 	}
-	//data.Extravars = jsonStringToMapValue(ctx, &resp.Diagnostics, restInfo.JobGetDataSourceModel.Extravars)
-	//data.Credentials = jsonStringToMapValue(ctx, &resp.Diagnostics, restInfo.JobGetDataSourceModel.Credentials)
-	if job.Output != "" {
-		data.Output = types.StringValue(job.Output)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Debug(ctx, fmt.Sprintf("read a job resource: %s, status: %s", data.ID.ValueString(), data.Status.ValueString()))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+// A job cannot be modified, changes to the job inputs require a new job. Only cx_profile_name can be updated in place.
+func (r *JobResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *JobResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// last_updated and sensitive_extravars_hash are not set on import
+	if data.LastUpdated.IsUnknown() {
+		data.LastUpdated = types.StringValue(time.Now().UTC().Format(time.RFC3339))
 	}
-	if job.Counter != 0 {
-		data.Counter = types.Int64Value(job.Counter)
+	if data.SensitiveExtravarsHash.IsUnknown() {
+		var sensitiveExtravars types.Map
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sensitive_extravars"), &sensitiveExtravars)...)
+		data.SensitiveExtravarsHash = newSensitiveExtravarsHash(ctx, sensitiveExtravars, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
-	if job.NoOfRecords != 0 {
-		data.NoOfRecords = types.Int64Value(job.NoOfRecords)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// ModifyPlan computes sensitive_extravars_hash from the configuration, and requires a new job when it changes.
+// After an import, extravars and sensitive_extravars are compared with the extravars of the job instead.
+// When a new job is planned, the extravars are validated against the form definition, and the credentials must exist.
+func (r *JobResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
 	}
-	if job.Target != "" {
-		data.Target = types.StringValue(job.Target)
+
+	var sensitiveExtravars types.Map
+	var plan, state *JobResourceModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sensitive_extravars"), &sensitiveExtravars)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	newJob := req.State.Raw.IsNull()
+	if !newJob {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	}
-	if job.Start != "" {
-		data.Start = types.StringValue(job.Start)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if job.End != "" {
-		data.End = types.StringValue(job.End)
+	// the hash is compared with the salt of the job, a new job gets a new salt at apply
+	var salt []byte
+	if !newJob {
+		salt = sensitiveExtravarsSalt(state.SensitiveExtravarsHash)
 	}
-	if job.Approval != "" {
-		data.Approval = types.StringValue(job.Approval)
+	hash := hashSensitiveExtravars(ctx, sensitiveExtravars, salt, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sensitive_extravars_hash"), hash)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Write logs using the tflog package
-	// Documentation: https://terraform.io/plugin/log
-	tflog.Debug(ctx, fmt.Sprintf("read a data source: %#v", data))
+	if !newJob {
+		if state.Extravars.IsNull() {
+			// imported job
+			if !r.importedExtravarsMatch(ctx, state, plan.Extravars, sensitiveExtravars, &resp.Diagnostics) {
+				resp.RequiresReplace = append(resp.RequiresReplace, path.Root("extravars"))
+			}
+		} else if !hash.Equal(state.SensitiveExtravarsHash) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("sensitive_extravars_hash"))
+		}
+		newJob = len(resp.RequiresReplace) != 0 || !plan.FormName.Equal(state.FormName) || (!state.Extravars.IsNull() && !plan.Extravars.Equal(state.Extravars))
+		if (newJob || !plan.Credentials.Equal(state.Credentials)) && !hash.IsNull() {
+			// the new job gets a new salt
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sensitive_extravars_hash"), types.StringUnknown())...)
+		}
+	}
+	if resp.Diagnostics.HasError() || !newJob {
+		return
+	}
+	if !plan.SkipFormValidation.ValueBool() {
+		r.validateExtravars(ctx, plan, sensitiveExtravars, &resp.Diagnostics)
+	}
+	if !plan.SkipCredentialCheck.ValueBool() {
+		r.checkCredentials(ctx, plan, &resp.Diagnostics)
+	}
+}
 
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+// importedExtravarsMatch reports whether the extravars of an imported job are extravars merged with sensitiveExtravars.
+// Values that are not strings in the job are compared with their JSON representation.
+func (r *JobResource) importedExtravarsMatch(ctx context.Context, state *JobResourceModel, extravars types.Map, sensitiveExtravars types.Map, diags *diag.Diagnostics) bool {
+	if extravars.IsUnknown() || sensitiveExtravars.IsUnknown() {
+		return false
+	}
+	configured := make(map[string]string, len(extravars.Elements())+len(sensitiveExtravars.Elements()))
+	var secrets []string
+	// sensitive_extravars take precedence over extravars
+	collect := func(vars types.Map, sensitive bool) bool {
+		for key, element := range vars.Elements() {
+			value, ok := element.(types.String)
+			if !ok || value.IsUnknown() {
+				return false
+			}
+			configured[key] = value.ValueString()
+			if sensitive {
+				secrets = append(secrets, value.ValueString())
+			}
+		}
+		return true
+	}
+	if !collect(extravars, false) || !collect(sensitiveExtravars, true) {
+		return false
+	}
+
+	ctx = utils.ContextWithSecrets(ctx, secrets...)
+	errorHandler := utils.NewErrorHandler(ctx, diags)
+	client, err := getRestClient(errorHandler, r.config, state.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return false
+	}
+	job, err := interfaces.GetJobByID(errorHandler, client, state.ID.ValueString())
+	if err != nil || job == nil {
+		return false
+	}
+	var actual map[string]any
+	if job.Extravars != "" {
+		if err = json.Unmarshal([]byte(job.Extravars), &actual); err != nil {
+			diags.AddError("error unmarshalling JSON string", err.Error())
+			return false
+		}
+	}
+	if len(actual) != len(configured) {
+		return false
+	}
+	for key, value := range actual {
+		expected, ok := configured[key]
+		if !ok {
+			return false
+		}
+		if s, isString := value.(string); isString {
+			if s != expected {
+				return false
+			}
+			continue
+		}
+		if document, err := json.Marshal(value); err != nil || string(document) != expected {
+			return false
+		}
+	}
+	return true
 }
 
-// Update updates the resource and sets the updated Terraform state on success.
-func (r *JobResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+// checkCredentials warns about the values of credentials that do not name an existing credential, as the credentials
+// may be created in the same apply.
+// Nothing is checked while the connection profile or the credentials are unknown.
+func (r *JobResource) checkCredentials(ctx context.Context, plan *JobResourceModel, diags *diag.Diagnostics) {
+	if plan.CxProfileName.IsUnknown() || plan.Credentials.IsUnknown() || len(plan.Credentials.Elements()) == 0 {
+		return
+	}
+	errorHandler := utils.NewErrorHandler(ctx, diags)
+	client, err := getRestClient(errorHandler, r.config, plan.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	names, err := r.config.providerConfig.GetCredentialNames(errorHandler, client, plan.CxProfileName.ValueString())
+	if err != nil {
+		// error reporting done inside GetCredentialNames
+		return
+	}
+	var problems []string
+	for key, element := range plan.Credentials.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsUnknown() || value.IsNull() {
+			continue
+		}
+		// credentials is sensitive, only the keys are reported
+		if !names[value.ValueString()] {
+			problems = append(problems, fmt.Sprintf("%s does not name an existing credential", key))
+		}
+	}
+	if len(problems) != 0 {
+		sort.Strings(problems)
+		diags.AddAttributeWarning(path.Root("credentials"), "credential not found",
+			fmt.Sprintf("On connection profile %s:\n%s\n\nThe job may fail unless the credentials are created before, eg with ansible-forms_credential. "+
+				"Set skip_credential_check to skip this check.", plan.CxProfileName.ValueString(), strings.Join(problems, "\n")))
+	}
+}
+
+// validateExtravars reports the extravars and sensitive_extravars that do not match the form definition.
+// Nothing is checked while the connection profile, the form name or the extravars are unknown, and a warning is
+// reported when the form does not exist.
+func (r *JobResource) validateExtravars(ctx context.Context, plan *JobResourceModel, sensitiveExtravars types.Map, diags *diag.Diagnostics) {
+	if plan.CxProfileName.IsUnknown() || plan.FormName.IsUnknown() || plan.Extravars.IsUnknown() || sensitiveExtravars.IsUnknown() {
+		return
+	}
+	extravars := make(map[string]*string, len(plan.Extravars.Elements())+len(sensitiveExtravars.Elements()))
+	for _, vars := range []types.Map{plan.Extravars, sensitiveExtravars} {
+		for key, element := range vars.Elements() {
+			value, ok := element.(types.String)
+			if !ok || value.IsUnknown() || value.IsNull() {
+				extravars[key] = nil
+				continue
+			}
+			extravars[key] = value.ValueStringPointer()
+		}
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, diags)
+	client, err := getRestClient(errorHandler, r.config, plan.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	form, err := r.config.providerConfig.GetForm(errorHandler, client, plan.CxProfileName.ValueString(), plan.FormName.ValueString())
+	if err != nil {
+		// error reporting done inside GetForm
+		return
+	}
+	if form == nil {
+		// the form may be created in the same apply, eg with ansible-forms_form
+		diags.AddAttributeWarning(path.Root("form_name"), "form not found",
+			fmt.Sprintf("Form %s does not exist, or is not visible to the user, extravars are not validated. "+
+				"Launching the job fails unless the form is created before, eg with ansible-forms_form.", plan.FormName.ValueString()))
+		return
+	}
+	if problems := form.ValidateExtravars(extravars); len(problems) != 0 {
+		diags.AddAttributeError(path.Root("extravars"), "extravars do not match the form definition",
+			fmt.Sprintf("%s\n\nSet skip_form_validation to launch the job anyway.", strings.Join(problems, "\n")))
+	}
+}
+
+// sensitiveExtravarsSaltSize is the size in bytes of the random salt of sensitive_extravars_hash.
+const sensitiveExtravarsSaltSize = 16
+
+// hashSensitiveExtravars returns the HMAC-SHA256 of the JSON representation of sensitiveExtravars keyed with salt, as
+// <salt>:<hmac> hex encoded, so that the secret values cannot be brute forced from the state with precomputed tables.
+// The hash is null when sensitiveExtravars is null, and unknown when any value is unknown or salt is nil, eg when the
+// job is planned: it is then computed at apply with a new salt, see newSensitiveExtravarsHash.
+func hashSensitiveExtravars(ctx context.Context, sensitiveExtravars types.Map, salt []byte, diags *diag.Diagnostics) types.String {
+	if sensitiveExtravars.IsNull() {
+		return types.StringNull()
+	}
+	if sensitiveExtravars.IsUnknown() || salt == nil {
+		return types.StringUnknown()
+	}
+	for _, value := range sensitiveExtravars.Elements() {
+		if value.IsUnknown() {
+			return types.StringUnknown()
+		}
+	}
+	var values map[string]string
+	diags.Append(sensitiveExtravars.ElementsAs(ctx, &values, false)...)
+	// encoding/json sorts map keys, so the document is stable
+	document, err := json.Marshal(values)
+	if err != nil {
+		diags.AddError("unable to hash sensitive_extravars", err.Error())
+		return types.StringUnknown()
+	}
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(document)
+
+	return types.StringValue(hex.EncodeToString(salt) + ":" + hex.EncodeToString(mac.Sum(nil)))
+}
+
+// sensitiveExtravarsSalt returns the salt of a hash returned by hashSensitiveExtravars, or nil when there is none.
+func sensitiveExtravarsSalt(hash types.String) []byte {
+	encoded, _, found := strings.Cut(hash.ValueString(), ":")
+	if !found {
+		return nil
+	}
+	salt, err := hex.DecodeString(encoded)
+	if err != nil || len(salt) != sensitiveExtravarsSaltSize {
+		return nil
+	}
+	return salt
+}
+
+// newSensitiveExtravarsHash returns the hash of sensitiveExtravars with a new random salt.
+func newSensitiveExtravarsHash(ctx context.Context, sensitiveExtravars types.Map, diags *diag.Diagnostics) types.String {
+	salt := make([]byte, sensitiveExtravarsSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		diags.AddError("unable to hash sensitive_extravars", err.Error())
+		return types.StringUnknown()
+	}
+	return hashSensitiveExtravars(ctx, sensitiveExtravars, salt, diags)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -330,8 +695,27 @@ func (r *JobResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		// error reporting done inside NewClient
 		return
 	}
-	err = interfaces.DeleteJobByID(errorHandler, *client, data.ID.ValueString())
+	err = interfaces.DeleteJobByID(errorHandler, client, data.ID.ValueString())
 	if err != nil {
 		return
 	}
 }
+
+// ImportState imports a job with an identifier in the format id,cx_profile_name.
+func (r *JobResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Debug(ctx, fmt.Sprintf("import req a job resource: %#v", req))
+	idParts := strings.Split(req.ID, ",")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: id,cx_profile_name. Got: %q", req.ID),
+		)
+		return
+	}
+	if _, err := strconv.ParseInt(idParts[0], 10, 64); err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", fmt.Sprintf("Expected a numeric job id. Got: %q", idParts[0]))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cx_profile_name"), idParts[1])...)
+}
@@ -1,74 +1,362 @@
 package provider
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
 	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"terraform-provider-ansible-forms/internal/fakeserver"
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/restclient/httpclient"
+	"terraform-provider-ansible-forms/internal/restclient/restclienttest"
 )
 
 func TestAccJobResource(t *testing.T) {
+	server := newTestAccServer(t)
+	steps := []resource.TestStep{
+		{
+			Config: server.providerConfig() + testAccJobResourceConfig("cluster4", "Demo Form Ansible No input"),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "form_name", "Demo Form Ansible No input"),
+				resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "status", "success"),
+				resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.name", "github.com/dsha256"),
+				resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.opco", "myopco"),
+				resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.svm_name", "mysvm_name"),
+				resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.exposure", "myexposure"),
+				resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.env", "myenv"),
+				resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.dataclass", "mydataclass"),
+				resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.share_name", "myshare_name"),
+				resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.accountid", "myaccountid"),
+				resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.size", "mysize"),
+				resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.protection_required", "myprotection_required"),
+				resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "credentials.ontap_cred", "myontap_cred"),
+				resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "credentials.bind_cred", "mybind_cred"),
+				// Check that an ID has been set (we don't know what the value is as it changes
+				resource.TestCheckResourceAttrSet("ansible-forms_job_resource.job", "id"),
+				resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.region", "myregion")),
+		},
+		{
+			ResourceName:      "ansible-forms_job_resource.job",
+			ImportState:       true,
+			ImportStateIdFunc: testAccJobResourceImportID("ansible-forms_job_resource.job", "cluster4"),
+			ImportStateVerify: true,
+			// extravars is not imported, see TestAccJobResource_importSensitiveExtravars
+			ImportStateVerifyIgnore: server.importStateVerifyIgnore([]string{"last_updated", "skip_form_validation", "extravars"}, "credentials"),
+		},
+		{
+			// only the connection profile can be updated without launching a new job
+			Config: server.providerConfig() + testAccJobResourceConfig("cluster5", "Demo Form Ansible No input"),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "cx_profile_name", "cluster5"),
+				resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "status", "success")),
+		},
+		{
+			Config:      server.providerConfig() + testAccJobResourceConfig("cluster5", "Non Existent Form Name"),
+			ExpectError: regexp.MustCompile("error creating job"),
+		},
+	}
+	var checkDestroy resource.TestCheckFunc
+	if server.fake != nil {
+		server.fake.AddForm(fakeserver.Form{Name: "Demo Form Ansible No input", Type: "ansible", Playbook: "demo.yaml"})
+		server.fake.AddForm(fakeserver.Form{Name: "Failing Form", Type: "ansible", Playbook: "failing.yaml", Outcome: fakeserver.JobStatusFailed})
+		server.fake.AddForm(fakeserver.Form{Name: "Approval Form", Type: "ansible", Playbook: "demo.yaml", Approval: &fakeserver.Approval{Title: "Approve"}})
+		// the job credentials must exist when a job is planned
+		server.fake.AddCredential(fakeserver.Credential{Name: "myontap_cred", Host: "ontap1", User: "admin"})
+		server.fake.AddCredential(fakeserver.Credential{Name: "mybind_cred", Host: "bind1", User: "admin"})
+		steps = append(steps,
+			resource.TestStep{
+				Config:      server.providerConfig() + testAccJobResourceConfig("cluster4", "Failing Form"),
+				ExpectError: regexp.MustCompile("job [0-9]+ did not succeed, status: failed"),
+			},
+			resource.TestStep{
+				// a job waiting for approval is created without error
+				Config: server.providerConfig() + testAccJobResourceConfig("cluster4", "Approval Form"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "status", "approve"),
+					resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "approval", `{"title":"Approve"}`)),
+			},
+		)
+		checkDestroy = func(_ *terraform.State) error {
+			if ids := server.fake.JobIDs(); len(ids) != 0 {
+				return fmt.Errorf("jobs %v still exist", ids)
+			}
+			return nil
+		}
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             checkDestroy,
+		Steps:                    steps,
+	})
+}
+
+func TestAccJobResource_formValidation(t *testing.T) {
+	server := newTestAccServer(t)
+	if server.fake == nil {
+		t.Skip("the form is only defined in the fake Ansible Forms server")
+	}
+	server.fake.AddForm(fakeserver.Form{Name: "Create Share", Type: "ansible", Playbook: "create_share.yaml", Fields: []map[string]any{
+		{"name": "share_name", "type": "text", "required": true, "regex": map[string]any{"expression": "^[a-z_]+$", "description": "lowercase letters"}},
+		{"name": "protocol", "type": "enum", "values": []string{"cifs", "nfs"}, "default": "cifs"},
+		{"name": "size", "type": "number", "required": true, "dependencies": []map[string]any{{"name": "protocol", "values": []string{"nfs"}}}},
+	}})
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// a missing form is only a warning at plan time, the job fails when it is launched
+				Config:      server.providerConfig() + testAccJobResourceValidationConfig("Non Existent Form Name", `share_name = "share"`),
+				ExpectError: regexp.MustCompile("error creating job"),
+			},
+			{
+				Config:      server.providerConfig() + testAccJobResourceValidationConfig("Create Share", `protocol = "smb"`),
+				ExpectError: regexp.MustCompile(`protocol must be one of cifs, nfs\s+share_name is required by form Create Share`),
+			},
+			{
+				Config:      server.providerConfig() + testAccJobResourceValidationConfig("Create Share", "share_name = \"Share\"\n    protocol = \"nfs\"\n    sise = \"10\""),
+				ExpectError: regexp.MustCompile(`share_name does not match \^\[a-z_\]\+\$ lowercase letters\s+sise is not a field of form Create Share\s+size is required by form Create Share`),
+			},
+			{
+				Config: server.providerConfig() + testAccJobResourceValidationConfig("Create Share", "share_name = \"share\"\n    protocol = \"nfs\"\n    size = \"10\""),
+				Check:  resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "status", "success"),
+			},
+			{
+				// the form is created in the same apply, it does not exist when the job is planned
+				Config: server.providerConfig() + `
+resource "ansible-forms_form" "form" {
+  cx_profile_name = "cluster4"
+  name            = "TF Job Form"
+  type            = "ansible"
+  playbook        = "create_share.yaml"
+  fields          = jsonencode([{ name = "share_name", type = "text", required = true }])
+}` + strings.Replace(testAccJobResourceValidationConfig("TF Job Form", `share_name = "share"`), `"TF Job Form"`, "ansible-forms_form.form.name", 1),
+				Check: resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "status", "success"),
+			},
+		},
+	})
+}
+
+func TestAccJobResource_credentialCheck(t *testing.T) {
+	server := newTestAccServer(t)
+	if server.fake == nil {
+		t.Skip("the credentials are only defined in the fake Ansible Forms server")
+	}
+	server.fake.AddForm(fakeserver.Form{Name: "Demo Form Ansible No input", Type: "ansible", Playbook: "demo.yaml"})
+	server.fake.AddCredential(fakeserver.Credential{Name: "myontap_cred", Host: "ontap1", User: "admin"})
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccJobResourceConfig("Demo Form Ansible No input"),
+				// mybind_cred does not exist, it is only a warning as it may be created in the same apply
+				Config: server.providerConfig() + testAccJobResourceConfig("cluster4", "Demo Form Ansible No input"),
+				Check:  resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "status", "success"),
+			},
+			{
+				Config: server.providerConfig() + strings.Replace(testAccJobResourceConfig("cluster4", "Demo Form Ansible No input"),
+					"skip_form_validation = true", "skip_form_validation = true\n  skip_credential_check = true", 1),
+				Check: resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "status", "success"),
+			},
+			{
+				// the credential is created with the job
+				Config: server.providerConfig() + testAccCredentialResourceConfig("mybind_cred", "ldap1") +
+					strings.Replace(testAccJobResourceConfig("cluster4", "Demo Form Ansible No input"), `"mybind_cred"`, "ansible-forms_credential.credential.name", 1),
+				Check: resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "status", "success"),
+			},
+		},
+	})
+}
+
+func TestAccJobResource_importSensitiveExtravars(t *testing.T) {
+	server := newTestAccServer(t)
+	if server.fake == nil {
+		t.Skip("the job is created directly in the fake Ansible Forms server")
+	}
+	// a job launched with extravars {share_name = "share"} and sensitive_extravars {api_key = "s3cr3t"}
+	server.fake.AddForm(fakeserver.Form{Name: "Create Share", Type: "ansible", Playbook: "create_share.yaml", Fields: []map[string]any{
+		{"name": "share_name", "type": "text", "required": true},
+		{"name": "api_key", "type": "password"},
+	}})
+	id := server.fake.AddJob("Create Share", map[string]any{"share_name": "share", "api_key": "s3cr3t"}, map[string]any{})
+	// Terraform 1.5 cannot set write-only attributes, api_key is configured in extravars to match the job
+	matching := testAccJobResourceValidationConfig("Create Share", "share_name = \"share\"\n    api_key = \"s3cr3t\"")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:             server.providerConfig() + matching,
+				ResourceName:       "ansible-forms_job_resource.job",
+				ImportState:        true,
+				ImportStateId:      fmt.Sprintf("%d,cluster4", id),
+				ImportStatePersist: true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported resource, got %d", len(states))
+					}
+					for key, value := range states[0].Attributes {
+						if strings.HasPrefix(key, "extravars") && key != "extravars.%" || strings.Contains(value, "s3cr3t") {
+							return fmt.Errorf("extravars of the job are imported: %s = %s", key, value)
+						}
+					}
+					return nil
+				},
+			},
+			{
+				// the configuration matches the job, it is stored in the state without launching a new job
+				Config: server.providerConfig() + matching,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "id", strconv.FormatInt(id, 10)),
+					resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.share_name", "share")),
+			},
+			{
+				// api_key is missing from the configuration, a new job is launched
+				Config: server.providerConfig() + testAccJobResourceValidationConfig("Create Share", `share_name = "share"`),
+				Check: resource.ComposeTestCheckFunc(
+					func(_ *terraform.State) error {
+						if ids := server.fake.JobIDs(); len(ids) != 1 || ids[0] == id {
+							return fmt.Errorf("expected the imported job %d to be replaced, jobs: %v", id, ids)
+						}
+						return nil
+					},
+					resource.TestCheckNoResourceAttr("ansible-forms_job_resource.job", "extravars.api_key")),
+			},
+		},
+	})
+}
+
+func TestAccJobResource_sensitiveExtravars(t *testing.T) {
+	server := newTestAccServer(t)
+	if server.fake == nil {
+		t.Skip("the values of the job are only read from the fake Ansible Forms server")
+	}
+	server.fake.AddForm(fakeserver.Form{Name: "Create Share", Type: "ansible", Playbook: "create_share.yaml", Fields: []map[string]any{
+		{"name": "share_name", "type": "text", "required": true},
+		{"name": "api_key", "type": "password"},
+	}})
+	var firstID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// write-only attributes require Terraform 1.11
+				SkipFunc: testAccSkipBelowTerraform("1.11.0"),
+				Config:   server.providerConfig() + testAccJobResourceSensitiveConfig("s3cr3t"),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "form_name", "Demo Form Ansible No input"),
-					resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.name", "github.com/dsha256"),
-					resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.opco", "myopco"),
-					resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.svm_name", "mysvm_name"),
-					resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.exposure", "myexposure"),
-					resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.env", "myenv"),
-					resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.dataclass", "mydataclass"),
-					resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.share_name", "myshare_name"),
-					resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.accountid", "myaccountid"),
-					resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.size", "mysize"),
-					resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.protection_required", "myprotection_required"),
-					resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "credentials.ontap_cred", "myontap_cred"),
-					resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "credentials.bind_cred", "mybind_cred"),
-					// Check that an ID has been set (we don't know what the value is as it changes
-					resource.TestCheckResourceAttrSet("ansible-forms_job_resource.job", "id"),
-					resource.TestCheckResourceAttr("ansible-forms_job_resource.job", "extravars.region", "myregion")),
+					resource.TestCheckNoResourceAttr("ansible-forms_job_resource.job", "sensitive_extravars.%"),
+					resource.TestMatchResourceAttr("ansible-forms_job_resource.job", "sensitive_extravars_hash", regexp.MustCompile("^[0-9a-f]{32}:[0-9a-f]{64}$")),
+					testAccCheckJobExtravar(server, "api_key", "s3cr3t"),
+					func(state *terraform.State) error {
+						firstID = state.RootModule().Resources["ansible-forms_job_resource.job"].Primary.ID
+						return nil
+					}),
 			},
 			{
-				Config:      testAccJobResourceConfig("Non Existent Form Name"),
-				ExpectError: regexp.MustCompile("Error running apply"),
+				// the same value is hashed with the salt of the job, no new job is launched
+				SkipFunc: testAccSkipBelowTerraform("1.11.0"),
+				Config:   server.providerConfig() + testAccJobResourceSensitiveConfig("s3cr3t"),
+				PlanOnly: true,
+			},
+			{
+				SkipFunc: testAccSkipBelowTerraform("1.11.0"),
+				Config:   server.providerConfig() + testAccJobResourceSensitiveConfig("r0tated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckJobExtravar(server, "api_key", "r0tated"),
+					func(state *terraform.State) error {
+						if id := state.RootModule().Resources["ansible-forms_job_resource.job"].Primary.ID; id == firstID {
+							return fmt.Errorf("job %s was not replaced", id)
+						}
+						return nil
+					}),
 			},
 		},
 	})
 }
 
-func testAccJobResourceConfig(jobFormName string) string {
-	host := os.Getenv("TF_ACC_ANSIBLE_FORMS_HOST")
-	//host := "127.0.0.1:8443"
-	admin := os.Getenv("TF_ACC_ANSIBLE_FORMS_USER")
-	//admin := "admin"
-	password := os.Getenv("TF_ACC_ANSIBLE_FORMS_PASS")
-	//password := "AnsibleForms!123"
-	if host == "" || admin == "" || password == "" {
-		fmt.Println("TF_ACC_ANSIBLE_FORMS_HOST, TF_ACC_ANSIBLE_FORMS_USER, and TF_ACC_ANSIBLE_FORMS_PASS must be set for acceptance tests")
-		os.Exit(1)
+// testAccCheckJobExtravar checks an extravar of the job in the fake Ansible Forms server.
+func testAccCheckJobExtravar(server testAccServer, key string, want string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		id, err := strconv.ParseInt(state.RootModule().Resources["ansible-forms_job_resource.job"].Primary.ID, 10, 64)
+		if err != nil {
+			return err
+		}
+		extravars, ok := server.fake.JobExtravars(id)
+		if !ok {
+			return fmt.Errorf("job %d not found", id)
+		}
+		if extravars[key] != want {
+			return fmt.Errorf("job %d extravar %s is not the configured value", id, key)
+		}
+		return nil
 	}
+}
+
+func testAccJobResourceSensitiveConfig(apiKey string) string {
 	return fmt.Sprintf(`
-provider "ansible-forms" {
- connection_profiles = [
-    {
-      name = "cluster4"
-      hostname = "%s"
-      username = "%s"
-      password = "%s"
-      validate_certs = false
-    },
-  ]
+resource "ansible-forms_job_resource" "job" {
+  cx_profile_name = "cluster4"
+  form_name       = "Create Share"
+  extravars = {
+    share_name = "share"
+  }
+  sensitive_extravars = {
+    api_key = "%s"
+  }
+  credentials = {}
+}`, apiKey)
+}
+
+func testAccJobResourceValidationConfig(formName string, extravars string) string {
+	return fmt.Sprintf(`
+resource "ansible-forms_job_resource" "job" {
+  cx_profile_name = "cluster4"
+  form_name       = "%s"
+  extravars = {
+    %s
+  }
+  credentials = {}
+}`, formName, extravars)
 }
 
+// testAccJobResourceImportID returns the import identifier of a job resource, in the format id,cx_profile_name.
+func testAccJobResourceImportID(resourceName string, cxProfileName string) resource.ImportStateIdFunc {
+	return func(state *terraform.State) (string, error) {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found", resourceName)
+		}
+		return fmt.Sprintf("%s,%s", rs.Primary.ID, cxProfileName), nil
+	}
+}
+
+func testAccJobResourceConfig(cxProfileName string, jobFormName string) string {
+	return fmt.Sprintf(`
 resource "ansible-forms_job_resource" "job" {
- cx_profile_name = "cluster4"
+ cx_profile_name = "%s"
   form_name       = "%s"
+  # the form has no fields, the extravars are only passed to the playbook
+  skip_form_validation = true
   extravars = {
     name                = "github.com/dsha256"
     region              = "myregion"
@@ -86,5 +374,350 @@ resource "ansible-forms_job_resource" "job" {
     ontap_cred = "myontap_cred"
     bind_cred  = "mybind_cred"
   }
-}`, host, admin, password, jobFormName)
+}`, cxProfileName, jobFormName)
+}
+
+// newTestJobResource returns a job resource using a mocked client, and a state with the job id.
+func newTestJobResource(t *testing.T, responses []restclienttest.MockResponse, id string) (*JobResource, tfsdk.State) {
+	client, err := restclienttest.NewMockedRestClient(t, responses)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newTestJobResourceWithClient(t, client, id)
+}
+
+// newTestJobResourceWithClient returns a job resource using client, and a state with the job id.
+func newTestJobResourceWithClient(t *testing.T, client restclient.Client, id string) (*JobResource, tfsdk.State) {
+	ctx := context.Background()
+	r := NewJobResource().(*JobResource)
+	var configureResp fwresource.ConfigureResponse
+	r.Configure(ctx, fwresource.ConfigureRequest{ProviderData: Config{client: client}}, &configureResp)
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	data := JobResourceModel{
+		CxProfileName:      types.StringValue("cluster4"),
+		ID:                 types.StringValue(id),
+		FormName:           types.StringValue("Demo Form"),
+		Status:             types.StringValue("running"),
+		Extravars:          types.MapNull(types.StringType),
+		SensitiveExtravars: types.MapNull(types.StringType),
+		Credentials:        types.MapNull(types.StringType),
+	}
+	if diags := state.Set(ctx, &data); diags.HasError() {
+		t.Fatal(diags)
+	}
+	return r, state
+}
+
+func TestJobResource_Read(t *testing.T) {
+	job := restclient.RestResponse{Status: "success", NumRecords: 1, Records: []map[string]any{{"id": 12, "formName": "Demo Form", "status": "success", "counter": 4, "extravars": `{"share_name":"share","api_key":"s3cr3t"}`}}}
+	tests := []struct {
+		name        string
+		responses   []restclienttest.MockResponse
+		wantRemoved bool
+		wantStatus  string
+		wantErr     bool
+	}{
+		{name: "found", wantStatus: "success", responses: []restclienttest.MockResponse{
+			{ExpectedMethod: "GET", ExpectedURL: "job/12", StatusCode: 200, Response: job},
+		}},
+		{name: "not_found", wantRemoved: true, responses: []restclienttest.MockResponse{
+			{ExpectedMethod: "GET", ExpectedURL: "job/12", StatusCode: 404, Err: &restclient.APIError{StatusCode: 404, Message: "job not found"}},
+		}},
+		{name: "server_error", wantStatus: "running", wantErr: true, responses: []restclienttest.MockResponse{
+			{ExpectedMethod: "GET", ExpectedURL: "job/12", StatusCode: 500, Err: &restclient.APIError{StatusCode: 500}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r, state := newTestJobResource(t, tt.responses, "12")
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("Read() diagnostics = %v, wantErr %v", resp.Diagnostics, tt.wantErr)
+			}
+			if resp.State.Raw.IsNull() != tt.wantRemoved {
+				t.Fatalf("Read() state removed = %v, want %v", resp.State.Raw.IsNull(), tt.wantRemoved)
+			}
+			if tt.wantRemoved {
+				return
+			}
+			var data JobResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if data.Status.ValueString() != tt.wantStatus {
+				t.Errorf("Read() status = %s, want %s", data.Status.ValueString(), tt.wantStatus)
+			}
+			// the extravars of the job include the sensitive extravars, they are not read
+			if !data.Extravars.IsNull() {
+				t.Errorf("Read() extravars = %s, want null", data.Extravars)
+			}
+		})
+	}
+}
+
+func TestHashSensitiveExtravars(t *testing.T) {
+	ctx := context.Background()
+	known := func(values map[string]string) types.Map {
+		m, diags := types.MapValueFrom(ctx, types.StringType, values)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		return m
+	}
+	salt := []byte("0123456789abcdef")
+	hash := func(m types.Map) types.String {
+		var diags diag.Diagnostics
+		h := hashSensitiveExtravars(ctx, m, salt, &diags)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		return h
+	}
+	partlyUnknown := types.MapValueMust(types.StringType, map[string]attr.Value{"a": types.StringValue("1"), "b": types.StringUnknown()})
+	tests := []struct {
+		name        string
+		value       types.Map
+		wantNull    bool
+		wantUnknown bool
+	}{
+		{name: "null", value: types.MapNull(types.StringType), wantNull: true},
+		{name: "unknown", value: types.MapUnknown(types.StringType), wantUnknown: true},
+		{name: "unknown_element", value: partlyUnknown, wantUnknown: true},
+		{name: "empty", value: known(map[string]string{})},
+		{name: "known", value: known(map[string]string{"api_key": "k", "db_pass": "p"})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hash(tt.value)
+			if got.IsNull() != tt.wantNull || got.IsUnknown() != tt.wantUnknown {
+				t.Fatalf("hashSensitiveExtravars() = %s, want null %v, unknown %v", got, tt.wantNull, tt.wantUnknown)
+			}
+		})
+	}
+
+	// the hash does not depend on the order of the keys, and changes with any value
+	first := hash(known(map[string]string{"api_key": "k", "db_pass": "p", "token": "t"}))
+	for i := 0; i < 20; i++ {
+		if got := hash(known(map[string]string{"token": "t", "db_pass": "p", "api_key": "k"})); !got.Equal(first) {
+			t.Fatalf("hashSensitiveExtravars() = %s, want %s", got, first)
+		}
+	}
+	if got := hash(known(map[string]string{"api_key": "k", "db_pass": "q", "token": "t"})); got.Equal(first) {
+		t.Errorf("hashSensitiveExtravars() = %s for a different value", got)
+	}
+
+	// the salt is stored with the hash, and changes it
+	if got := sensitiveExtravarsSalt(first); !bytes.Equal(got, salt) {
+		t.Errorf("sensitiveExtravarsSalt() = %x, want %x", got, salt)
+	}
+	salt = []byte("fedcba9876543210")
+	if got := hash(known(map[string]string{"api_key": "k", "db_pass": "p", "token": "t"})); got.Equal(first) {
+		t.Errorf("hashSensitiveExtravars() = %s for a different salt", got)
+	}
+	salt = nil
+	if got := hash(known(map[string]string{"api_key": "k"})); !got.IsUnknown() {
+		t.Errorf("hashSensitiveExtravars() = %s without salt, want unknown", got)
+	}
+	for _, value := range []string{"", "abc", "zz:00", "0011:00"} {
+		if got := sensitiveExtravarsSalt(types.StringValue(value)); got != nil {
+			t.Errorf("sensitiveExtravarsSalt(%q) = %x, want nil", value, got)
+		}
+	}
+	var diags diag.Diagnostics
+	if got := newSensitiveExtravarsHash(ctx, known(map[string]string{"api_key": "k"}), &diags); got.IsUnknown() || sensitiveExtravarsSalt(got) == nil {
+		t.Errorf("newSensitiveExtravarsHash() = %s, want a salted hash", got)
+	}
+}
+
+func TestMergeExtravars(t *testing.T) {
+	got := mergeExtravars(map[string]string{"name": "share", "api_key": "placeholder"}, map[string]string{"api_key": "k", "db_pass": "p"})
+	want := map[string]any{"name": "share", "api_key": "k", "db_pass": "p"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeExtravars() = %v, want %v", got, want)
+	}
+	if got := mergeExtravars(map[string]string{"name": "share"}, nil); !reflect.DeepEqual(got, map[string]any{"name": "share"}) {
+		t.Errorf("mergeExtravars() = %v, want only extravars", got)
+	}
+}
+
+func TestJobResource_ModifyPlan(t *testing.T) {
+	ctx := context.Background()
+	r := NewJobResource().(*JobResource)
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	secrets := func(values map[string]string) types.Map {
+		m, diags := types.MapValueFrom(ctx, types.StringType, values)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		return m
+	}
+	model := func(sensitiveExtravars types.Map) JobResourceModel {
+		return JobResourceModel{
+			CxProfileName:          types.StringValue("cluster4"),
+			ID:                     types.StringValue("12"),
+			LastUpdated:            types.StringNull(),
+			FormName:               types.StringValue("Demo Form"),
+			Status:                 types.StringValue("success"),
+			Extravars:              secrets(map[string]string{"name": "share"}),
+			SensitiveExtravars:     sensitiveExtravars,
+			SensitiveExtravarsHash: types.StringNull(),
+			Credentials:            types.MapNull(types.StringType),
+			// the form and the credentials are not read from a server
+			SkipFormValidation:  types.BoolValue(true),
+			SkipCredentialCheck: types.BoolValue(true),
+			Target:              types.StringNull(),
+			Output:              types.StringNull(),
+			Counter:             types.Int64Null(),
+			NoOfRecords:         types.Int64Null(),
+			Start:               types.StringNull(),
+			End:                 types.StringNull(),
+			Approval:            types.StringNull(),
+		}
+	}
+	hashOf := func(values map[string]string) types.String {
+		var diags diag.Diagnostics
+		return hashSensitiveExtravars(ctx, secrets(values), []byte("0123456789abcdef"), &diags)
+	}
+	tests := []struct {
+		name         string
+		stateHash    types.String
+		config       types.Map
+		wantReplace  bool
+		wantPlanHash types.String
+	}{
+		{name: "unchanged", stateHash: hashOf(map[string]string{"api_key": "k"}), config: secrets(map[string]string{"api_key": "k"}), wantPlanHash: hashOf(map[string]string{"api_key": "k"})},
+		{name: "changed", stateHash: hashOf(map[string]string{"api_key": "k"}), config: secrets(map[string]string{"api_key": "k2"}), wantReplace: true, wantPlanHash: types.StringUnknown()},
+		{name: "added", stateHash: types.StringNull(), config: secrets(map[string]string{"api_key": "k"}), wantReplace: true, wantPlanHash: types.StringUnknown()},
+		{name: "removed", stateHash: hashOf(map[string]string{"api_key": "k"}), config: types.MapNull(types.StringType), wantReplace: true, wantPlanHash: types.StringNull()},
+		{name: "unknown", stateHash: hashOf(map[string]string{"api_key": "k"}), config: types.MapUnknown(types.StringType), wantReplace: true, wantPlanHash: types.StringUnknown()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateData := model(types.MapNull(types.StringType))
+			stateData.SensitiveExtravarsHash = tt.stateHash
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			// the configuration has the write-only value, the plan does not
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			config := tfsdk.Plan{Schema: schemaResp.Schema}
+			var diags diag.Diagnostics
+			diags.Append(state.Set(ctx, &stateData)...)
+			diags.Append(plan.Set(ctx, &stateData)...)
+			configData := model(tt.config)
+			diags.Append(config.Set(ctx, &configData)...)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			req := fwresource.ModifyPlanRequest{State: state, Plan: plan, Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}
+			resp := fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("ModifyPlan() diagnostics = %v", resp.Diagnostics)
+			}
+			if replace := len(resp.RequiresReplace) != 0; replace != tt.wantReplace {
+				t.Errorf("ModifyPlan() RequiresReplace = %v, want replace %v", resp.RequiresReplace, tt.wantReplace)
+			}
+			var planHash types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("sensitive_extravars_hash"), &planHash)...)
+			if !planHash.Equal(tt.wantPlanHash) {
+				t.Errorf("ModifyPlan() sensitive_extravars_hash = %s, want %s", planHash, tt.wantPlanHash)
+			}
+		})
+	}
+}
+
+func TestJobResource_validateExtravars(t *testing.T) {
+	config := restclient.RestResponse{Status: "success", NumRecords: 1, Records: []map[string]any{{"forms": []any{
+		map[string]any{"name": "Create Share", "type": "ansible", "fields": []any{map[string]any{"name": "share_name", "type": "text", "required": true}}},
+	}}}}
+	tests := []struct {
+		name         string
+		formName     string
+		extravars    map[string]string
+		wantWarnings int
+		wantErr      bool
+	}{
+		{name: "valid", formName: "Create Share", extravars: map[string]string{"share_name": "share"}},
+		{name: "mismatch", formName: "Create Share", extravars: map[string]string{"sise": "10"}, wantErr: true},
+		// the form may be created in the same apply
+		{name: "form_not_found", formName: "TF Job Form", extravars: map[string]string{"share_name": "share"}, wantWarnings: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r, _ := newTestJobResource(t, []restclienttest.MockResponse{{ExpectedMethod: "GET", ExpectedURL: "config", StatusCode: 200, Response: config}}, "12")
+			extravars, diags := types.MapValueFrom(ctx, types.StringType, tt.extravars)
+			plan := &JobResourceModel{CxProfileName: types.StringValue("cluster4"), FormName: types.StringValue(tt.formName), Extravars: extravars}
+			r.validateExtravars(ctx, plan, types.MapNull(types.StringType), &diags)
+			if diags.HasError() != tt.wantErr || diags.WarningsCount() != tt.wantWarnings {
+				t.Errorf("validateExtravars() diagnostics = %v, wantErr %v, want %d warnings", diags, tt.wantErr, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestJobResource_checkCredentials(t *testing.T) {
+	credentials := restclient.RestResponse{Status: "success", NumRecords: 1, Records: []map[string]any{{"name": "myontap_cred", "host": "ontap1", "user": "admin"}}}
+	tests := []struct {
+		name         string
+		credentials  map[string]string
+		wantWarnings int
+	}{
+		{name: "found", credentials: map[string]string{"ontap_cred": "myontap_cred"}},
+		// the credential may be created in the same apply
+		{name: "not_found", credentials: map[string]string{"ontap_cred": "myontap_cred", "bind_cred": "mybind_cred"}, wantWarnings: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r, _ := newTestJobResource(t, []restclienttest.MockResponse{{ExpectedMethod: "GET", ExpectedURL: "credential/", StatusCode: 200, Response: credentials}}, "12")
+			planCredentials, diags := types.MapValueFrom(ctx, types.StringType, tt.credentials)
+			plan := &JobResourceModel{CxProfileName: types.StringValue("cluster4"), Credentials: planCredentials}
+			r.checkCredentials(ctx, plan, &diags)
+			if diags.HasError() || diags.WarningsCount() != tt.wantWarnings {
+				t.Errorf("checkCredentials() diagnostics = %v, want %d warnings", diags, tt.wantWarnings)
+			}
+			for _, d := range diags {
+				if strings.Contains(d.Detail(), "mybind_cred") {
+					t.Errorf("checkCredentials() reported a credential value: %s", d.Detail())
+				}
+			}
+		})
+	}
+}
+
+func TestJobResource_Read_redactsExtravars(t *testing.T) {
+	// a job launched with sensitive_extravars = { api_key = "s3cr3t" }, the secret is not known when the job is read
+	server := fakeserver.New(t)
+	id := server.AddJob("Create Share", map[string]any{"share_name": "share", "api_key": "s3cr3t"}, map[string]any{})
+	cassettePath := filepath.Join(t.TempDir(), "job.json")
+	t.Setenv(httpclient.CassetteModeEnv, httpclient.CassetteModeRecord)
+	t.Setenv(httpclient.CassetteEnv, cassettePath)
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	client, err := restclient.NewClient(ctx, restclient.ConnectionProfile{Hostname: server.Hostname(), Username: server.Username, Password: server.Password}, "job_resource", 600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, state := newTestJobResourceWithClient(t, client, strconv.FormatInt(id, 10))
+
+	resp := fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read() diagnostics = %v", resp.Diagnostics)
+	}
+	cassette, err := os.ReadFile(cassettePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(cassette), "api_key") || strings.Contains(string(cassette), "s3cr3t") {
+		t.Errorf("Read() recorded the extravars values in the cassette: %s", cassette)
+	}
+	if !strings.Contains(output.String(), "api_key") || strings.Contains(output.String(), "s3cr3t") {
+		t.Errorf("Read() logged the extravars values: %s", output.String())
+	}
 }
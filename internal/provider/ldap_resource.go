@@ -0,0 +1,337 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/interfaces"
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &LDAPResource{}
+	_ resource.ResourceWithConfigure   = &LDAPResource{}
+	_ resource.ResourceWithImportState = &LDAPResource{}
+)
+
+// ldapConnectionOK is the connection_test value when the connection test succeeds.
+const ldapConnectionOK = "ok"
+
+// NewLDAPResource is a helper function to simplify the provider implementation.
+func NewLDAPResource() resource.Resource {
+	return &LDAPResource{
+		config: resourceOrDataSourceConfig{
+			name: "ldap",
+		},
+	}
+}
+
+// LDAPResource is the resource implementation.
+type LDAPResource struct {
+	config resourceOrDataSourceConfig
+}
+
+// LDAPResourceModel maps the resource schema data.
+type LDAPResourceModel struct {
+	CxProfileName       types.String `tfsdk:"cx_profile_name"`
+	ID                  types.String `tfsdk:"id"`
+	Enable              types.Bool   `tfsdk:"enable"`
+	Server              types.String `tfsdk:"server"`
+	Port                types.Int64  `tfsdk:"port"`
+	EnableTLS           types.Bool   `tfsdk:"enable_tls"`
+	IgnoreCerts         types.Bool   `tfsdk:"ignore_certs"`
+	CACert              types.String `tfsdk:"ca_cert"`
+	BindUserDN          types.String `tfsdk:"bind_user_dn"`
+	BindPassword        types.String `tfsdk:"bind_password"`
+	BindPasswordVersion types.Int64  `tfsdk:"bind_password_version"`
+	SearchBase          types.String `tfsdk:"search_base"`
+	UsernameAttribute   types.String `tfsdk:"username_attribute"`
+	GroupsSearchBase    types.String `tfsdk:"groups_search_base"`
+	GroupsAttribute     types.String `tfsdk:"groups_attribute"`
+	ConnectionTest      types.String `tfsdk:"connection_test"`
+}
+
+// Metadata returns the resource type name.
+func (r *LDAPResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.config.name
+}
+
+// Schema defines the schema for the resource.
+func (r *LDAPResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages the LDAP authentication configuration of Ansible Forms. There is one LDAP configuration per Ansible Forms " +
+			"instance: the resource takes it over when it is created, and disables LDAP when it is destroyed. " +
+			"The connection is tested after each change.",
+
+		Attributes: map[string]schema.Attribute{
+			"cx_profile_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Connection profile name.",
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Identifier of the LDAP configuration, the connection profile name.",
+			},
+			"enable": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether users can log in with LDAP.",
+			},
+			"server": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "LDAP server host name, eg a domain controller.",
+			},
+			"port": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(389),
+				MarkdownDescription: "LDAP server port, usually 636 with `enable_tls`.",
+			},
+			"enable_tls": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the connection to the LDAP server uses TLS.",
+			},
+			"ignore_certs": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the LDAP server certificate is accepted without verification.",
+			},
+			"ca_cert": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				MarkdownDescription: "PEM encoded CA certificate used to verify the LDAP server certificate.",
+			},
+			"bind_user_dn": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Distinguished name or user principal name of the user that searches the directory.",
+			},
+			"search_base": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Base DN of the user search, eg `dc=example,dc=com`.",
+			},
+			"username_attribute": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("sAMAccountName"),
+				MarkdownDescription: "Attribute matched with the login name, eg `uid` for OpenLDAP.",
+			},
+			"groups_search_base": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				MarkdownDescription: "Base DN of the group search. When empty, the groups are read from `groups_attribute` of the user.",
+			},
+			"groups_attribute": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("memberOf"),
+				MarkdownDescription: "Attribute listing the groups of a user, that roles refer to as `ldap/<group>`.",
+			},
+			"connection_test": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Result of the connection test run after the last change: `" + ldapConnectionOK + "`, or the reason of the failure. " +
+					"A failed test is reported as a warning, as the LDAP server may not be reachable from where Terraform runs.",
+			},
+		},
+	}
+	maps.Copy(resp.Schema.Attributes, writeOnlySecretAttributes("bind_password", "Password of the bind user"))
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *LDAPResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected LDAP Resource Configure Type",
+			fmt.Sprintf("Expected Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	}
+	r.config.providerConfig = config
+}
+
+// ldapBody returns the body of an LDAP configuration request, with the bind password when it is not null.
+func ldapBody(data *LDAPResourceModel, password types.String) interfaces.LDAPResourceModel {
+	return interfaces.LDAPResourceModel{
+		Server:            data.Server.ValueString(),
+		Port:              data.Port.ValueInt64(),
+		IgnoreCerts:       data.IgnoreCerts.ValueBool(),
+		EnableTLS:         data.EnableTLS.ValueBool(),
+		Cert:              data.CACert.ValueString(),
+		BindUserDN:        data.BindUserDN.ValueString(),
+		BindUserPassword:  password.ValueString(),
+		SearchBase:        data.SearchBase.ValueString(),
+		UsernameAttribute: data.UsernameAttribute.ValueString(),
+		GroupsSearchBase:  data.GroupsSearchBase.ValueString(),
+		GroupsAttribute:   data.GroupsAttribute.ValueString(),
+		Enable:            data.Enable.ValueBool(),
+	}
+}
+
+// apply saves the LDAP configuration and tests the connection.
+func (r *LDAPResource) apply(ctx context.Context, diags *diag.Diagnostics, data *LDAPResourceModel, password types.String) {
+	errorHandler := utils.NewErrorHandler(ctx, diags)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	if err = interfaces.UpdateLDAP(errorHandler, client, ldapBody(data, password)); err != nil {
+		return
+	}
+	data.ID = data.CxProfileName
+	data.ConnectionTest = r.connectionTest(errorHandler, diags, client)
+}
+
+// connectionTest tests the connection to the LDAP server, and reports a warning when it fails.
+func (r *LDAPResource) connectionTest(errorHandler *utils.ErrorHandler, diags *diag.Diagnostics, client restclient.Client) types.String {
+	reason, err := interfaces.CheckLDAP(errorHandler, client)
+	if err != nil {
+		return types.StringNull()
+	}
+	if reason != "" {
+		diags.AddWarning("LDAP connection test failed",
+			fmt.Sprintf("The LDAP configuration is saved, but Ansible Forms cannot connect to the LDAP server: %s", reason))
+		return types.StringValue(reason)
+	}
+	return types.StringValue(ldapConnectionOK)
+}
+
+// Create takes over the LDAP configuration.
+func (r *LDAPResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *LDAPResourceModel
+	var password types.String
+
+	// Read Terraform plan data into the model, the bind password is write-only so it is only present in the configuration
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("bind_password"), &password)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(ctx, &resp.Diagnostics, data, password)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("created a ldap resource: %s, connection test %s", data.Server.ValueString(), data.ConnectionTest.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read resource information.
+func (r *LDAPResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *LDAPResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	ldap, err := interfaces.GetLDAP(errorHandler, client)
+	if err != nil {
+		return
+	}
+	data.ID = data.CxProfileName
+	data.Enable = types.BoolValue(ldap.Enable)
+	data.Server = types.StringValue(ldap.Server)
+	data.Port = types.Int64Value(ldap.Port)
+	data.EnableTLS = types.BoolValue(ldap.EnableTLS)
+	data.IgnoreCerts = types.BoolValue(ldap.IgnoreCerts)
+	data.CACert = types.StringValue(ldap.Cert)
+	data.BindUserDN = types.StringValue(ldap.BindUserDN)
+	data.SearchBase = types.StringValue(ldap.SearchBase)
+	data.UsernameAttribute = types.StringValue(ldap.UsernameAttribute)
+	data.GroupsSearchBase = types.StringValue(ldap.GroupsSearchBase)
+	data.GroupsAttribute = types.StringValue(ldap.GroupsAttribute)
+
+	tflog.Debug(ctx, fmt.Sprintf("read a ldap resource: %s, enable %t", data.Server.ValueString(), ldap.Enable))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update saves the LDAP configuration, the bind password is only sent when bind_password_version changed.
+func (r *LDAPResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *LDAPResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	password, diags := readWriteOnlyIfVersionChanged(ctx, req.Config, path.Root("bind_password"), data.BindPasswordVersion, state.BindPasswordVersion)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(ctx, &resp.Diagnostics, data, password)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("updated a ldap resource: %s, connection test %s", data.Server.ValueString(), data.ConnectionTest.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete disables LDAP, the other settings are left unchanged.
+func (r *LDAPResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *LDAPResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	data.Enable = types.BoolValue(false)
+	_ = interfaces.UpdateLDAP(errorHandler, client, ldapBody(data, types.StringNull()))
+}
+
+// ImportState imports the LDAP configuration of the connection profile given as identifier.
+// The bind password is not imported, set bind_password_version to send it on the next apply.
+func (r *LDAPResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", "Expected the connection profile name as import identifier.")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cx_profile_name"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
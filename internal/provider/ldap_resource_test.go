@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccLDAPResource(t *testing.T) {
+	server := newTestAccServer(t)
+	if server.fake == nil {
+		t.Skip("the LDAP configuration of a real server is not replaced by acceptance tests")
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(_ *terraform.State) error {
+			// LDAP is disabled, the other settings are kept
+			if ldap := server.fake.LDAP(); ldap.Enable || ldap.Server != "dc1.example.com" {
+				return fmt.Errorf("ldap enable = %t, server = %s, want false and dc1.example.com", ldap.Enable, ldap.Server)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				// the configuration is saved even when the server is not reachable
+				Config: server.providerConfig() + testAccLDAPResourceConfig("dc1.example.invalid", 389, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ansible-forms_ldap.ldap", "id", "cluster4"),
+					resource.TestCheckResourceAttr("ansible-forms_ldap.ldap", "enable", "true"),
+					resource.TestCheckResourceAttr("ansible-forms_ldap.ldap", "username_attribute", "sAMAccountName"),
+					resource.TestMatchResourceAttr("ansible-forms_ldap.ldap", "connection_test", regexp.MustCompile("ECONNREFUSED")),
+					resource.TestCheckNoResourceAttr("ansible-forms_ldap.ldap", "bind_password")),
+			},
+			{
+				ResourceName:            "ansible-forms_ldap.ldap",
+				ImportState:             true,
+				ImportStateId:           "cluster4",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"connection_test"},
+			},
+			{
+				// the bind password is set outside of Terraform, as write-only attributes need Terraform 1.11
+				PreConfig: func() {
+					ldap := server.fake.LDAP()
+					ldap.BindUserPassword = "secret"
+					server.fake.ReplaceLDAP(ldap)
+				},
+				Config: server.providerConfig() + testAccLDAPResourceConfig("dc1.example.com", 636, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ansible-forms_ldap.ldap", "port", "636"),
+					resource.TestCheckResourceAttr("ansible-forms_ldap.ldap", "connection_test", "ok"),
+					func(_ *terraform.State) error {
+						if ldap := server.fake.LDAP(); !ldap.EnableTLS || ldap.BindUserPassword != "secret" {
+							return fmt.Errorf("ldap enable_tls = %t, password changed %v, want true and the password to be kept", ldap.EnableTLS, ldap.BindUserPassword != "secret")
+						}
+						return nil
+					}),
+			},
+		},
+	})
+}
+
+func testAccLDAPResourceConfig(host string, port int, tls bool) string {
+	return fmt.Sprintf(`
+resource "ansible-forms_ldap" "ldap" {
+  cx_profile_name = "cluster4"
+  server          = "%s"
+  port            = %d
+  enable_tls      = %t
+  bind_user_dn    = "cn=ansibleforms,ou=services,dc=example,dc=com"
+  search_base     = "dc=example,dc=com"
+}`, host, port, tls)
+}
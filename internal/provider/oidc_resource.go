@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/interfaces"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &OIDCResource{}
+	_ resource.ResourceWithConfigure   = &OIDCResource{}
+	_ resource.ResourceWithImportState = &OIDCResource{}
+)
+
+// NewOIDCResource is a helper function to simplify the provider implementation.
+func NewOIDCResource() resource.Resource {
+	return &OIDCResource{
+		config: resourceOrDataSourceConfig{
+			name: "oidc",
+		},
+	}
+}
+
+// OIDCResource is the resource implementation.
+type OIDCResource struct {
+	config resourceOrDataSourceConfig
+}
+
+// OIDCResourceModel maps the resource schema data.
+type OIDCResourceModel struct {
+	CxProfileName       types.String `tfsdk:"cx_profile_name"`
+	ID                  types.String `tfsdk:"id"`
+	Enable              types.Bool   `tfsdk:"enable"`
+	Issuer              types.String `tfsdk:"issuer"`
+	ClientID            types.String `tfsdk:"client_id"`
+	ClientSecret        types.String `tfsdk:"client_secret"`
+	ClientSecretVersion types.Int64  `tfsdk:"client_secret_version"`
+	GroupFilter         types.String `tfsdk:"group_filter"`
+}
+
+// Metadata returns the resource type name.
+func (r *OIDCResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.config.name
+}
+
+// Schema defines the schema for the resource.
+func (r *OIDCResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages the generic OpenID Connect (OIDC) single sign-on configuration of Ansible Forms. There is one OIDC configuration per Ansible Forms " +
+			"instance: the resource takes it over when it is created, and disables OIDC login when it is destroyed.",
+
+		Attributes: map[string]schema.Attribute{
+			"cx_profile_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Connection profile name.",
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Identifier of the OIDC configuration, the connection profile name.",
+			},
+			"enable": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether users can log in with OIDC.",
+			},
+			"issuer": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Issuer URL of the identity provider, where `.well-known/openid-configuration` is found.",
+			},
+			"client_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Client id of Ansible Forms at the identity provider.",
+			},
+			"group_filter": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				MarkdownDescription: "Regular expression selecting the groups of a user that are kept, eg `^forms_`. All the groups are kept when empty.",
+			},
+		},
+	}
+	maps.Copy(resp.Schema.Attributes, writeOnlySecretAttributes("client_secret", "Client secret of Ansible Forms at the identity provider"))
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *OIDCResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected OIDC Resource Configure Type",
+			fmt.Sprintf("Expected Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	}
+	r.config.providerConfig = config
+}
+
+// oidcBody returns the body of an OIDC configuration request, with the client secret when it is not null.
+func oidcBody(data *OIDCResourceModel, secret types.String) interfaces.OIDCResourceModel {
+	return interfaces.OIDCResourceModel{
+		Issuer:       data.Issuer.ValueString(),
+		ClientID:     data.ClientID.ValueString(),
+		ClientSecret: secret.ValueString(),
+		GroupFilter:  data.GroupFilter.ValueString(),
+		Enable:       data.Enable.ValueBool(),
+	}
+}
+
+// apply saves the OIDC configuration.
+func (r *OIDCResource) apply(errorHandler *utils.ErrorHandler, data *OIDCResourceModel, secret types.String) {
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	if err = interfaces.UpdateOIDC(errorHandler, client, oidcBody(data, secret)); err != nil {
+		return
+	}
+	data.ID = data.CxProfileName
+}
+
+// Create takes over the OIDC configuration.
+func (r *OIDCResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *OIDCResourceModel
+	var secret types.String
+
+	// Read Terraform plan data into the model, the client secret is write-only so it is only present in the configuration
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("client_secret"), &secret)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(utils.NewErrorHandler(ctx, &resp.Diagnostics), data, secret)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("created a oidc resource: %s", data.Issuer.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read resource information.
+func (r *OIDCResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *OIDCResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	oidc, err := interfaces.GetOIDC(errorHandler, client)
+	if err != nil {
+		return
+	}
+	data.ID = data.CxProfileName
+	data.Enable = types.BoolValue(oidc.Enable)
+	data.Issuer = types.StringValue(oidc.Issuer)
+	data.ClientID = types.StringValue(oidc.ClientID)
+	data.GroupFilter = types.StringValue(oidc.GroupFilter)
+
+	tflog.Debug(ctx, fmt.Sprintf("read a oidc resource: %s, enable %t", data.Issuer.ValueString(), oidc.Enable))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update saves the OIDC configuration, the client secret is only sent when client_secret_version changed.
+func (r *OIDCResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *OIDCResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	secret, diags := readWriteOnlyIfVersionChanged(ctx, req.Config, path.Root("client_secret"), data.ClientSecretVersion, state.ClientSecretVersion)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(utils.NewErrorHandler(ctx, &resp.Diagnostics), data, secret)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete disables OIDC login, the other settings are left unchanged.
+func (r *OIDCResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *OIDCResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	data.Enable = types.BoolValue(false)
+	_ = interfaces.UpdateOIDC(errorHandler, client, oidcBody(data, types.StringNull()))
+}
+
+// ImportState imports the OIDC configuration of the connection profile given as identifier.
+// The client secret is not imported, set client_secret_version to send it on the next apply.
+func (r *OIDCResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", "Expected the connection profile name as import identifier.")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cx_profile_name"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccOIDCResource(t *testing.T) {
+	server := newTestAccServer(t)
+	if server.fake == nil {
+		t.Skip("the OIDC configuration of a real server is not replaced by acceptance tests")
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(_ *terraform.State) error {
+			if oidc := server.fake.OIDC(); oidc.Enable || oidc.Issuer != "https://login.example.com/realms/prod" {
+				return fmt.Errorf("oidc enable = %t, issuer = %s, want false and the issuer to be kept", oidc.Enable, oidc.Issuer)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      server.providerConfig() + testAccOIDCResourceConfig("", true),
+				ExpectError: regexp.MustCompile(`issuer and client_id are required when oidc is enabled`),
+			},
+			{
+				Config: server.providerConfig() + testAccOIDCResourceConfig("https://login.example.com/realms/dev", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ansible-forms_oidc.oidc", "id", "cluster4"),
+					resource.TestCheckResourceAttr("ansible-forms_oidc.oidc", "enable", "false"),
+					resource.TestCheckNoResourceAttr("ansible-forms_oidc.oidc", "client_secret")),
+			},
+			{
+				ResourceName:      "ansible-forms_oidc.oidc",
+				ImportState:       true,
+				ImportStateId:     "cluster4",
+				ImportStateVerify: true,
+			},
+			{
+				Config: server.providerConfig() + testAccOIDCResourceConfig("https://login.example.com/realms/prod", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ansible-forms_oidc.oidc", "issuer", "https://login.example.com/realms/prod"),
+					resource.TestCheckResourceAttr("ansible-forms_oidc.oidc", "enable", "true")),
+			},
+		},
+	})
+}
+
+func testAccOIDCResourceConfig(issuer string, enable bool) string {
+	return fmt.Sprintf(`
+resource "ansible-forms_oidc" "oidc" {
+  cx_profile_name = "cluster4"
+  issuer          = "%s"
+  client_id       = "ansible-forms"
+  enable          = %t
+}`, issuer, enable)
+}
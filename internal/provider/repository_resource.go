@@ -0,0 +1,353 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/interfaces"
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &RepositoryResource{}
+	_ resource.ResourceWithConfigure   = &RepositoryResource{}
+	_ resource.ResourceWithImportState = &RepositoryResource{}
+)
+
+// NewRepositoryResource is a helper function to simplify the provider implementation.
+func NewRepositoryResource() resource.Resource {
+	return &RepositoryResource{
+		config: resourceOrDataSourceConfig{
+			name: "repository",
+		},
+	}
+}
+
+// RepositoryResource is the resource implementation.
+type RepositoryResource struct {
+	config resourceOrDataSourceConfig
+}
+
+// RepositoryResourceModel maps the resource schema data.
+type RepositoryResourceModel struct {
+	CxProfileName   types.String `tfsdk:"cx_profile_name"`
+	Name            types.String `tfsdk:"name"`
+	ID              types.String `tfsdk:"id"`
+	URI             types.String `tfsdk:"uri"`
+	Branch          types.String `tfsdk:"branch"`
+	Credential      types.String `tfsdk:"credential"`
+	UseForForms     types.Bool   `tfsdk:"use_for_forms"`
+	UseForPlaybooks types.Bool   `tfsdk:"use_for_playbooks"`
+	Cron            types.String `tfsdk:"cron"`
+	PullOnApply     types.String `tfsdk:"pull_on_apply"`
+	Status          types.String `tfsdk:"status"`
+	LastCommit      types.String `tfsdk:"last_commit"`
+}
+
+// Metadata returns the resource type name.
+func (r *RepositoryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.config.name
+}
+
+// Schema defines the schema for the resource.
+func (r *RepositoryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a git repository cloned by Ansible Forms, for playbooks or forms. " +
+			"Ansible Forms clones the repository when it is created, and pulls it on `cron` or when `pull_on_apply` changes. " +
+			"A failed clone or pull is reported as a warning and in `status`.",
+
+		Attributes: map[string]schema.Attribute{
+			"cx_profile_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Connection profile name.",
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "Repository name, unique in Ansible Forms, also the name of the directory it is cloned in.",
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Repository identifier.",
+			},
+			"uri": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+				MarkdownDescription: "Git URL of the repository, https or ssh. It is sensitive as it may hold a token, " +
+					"prefer `credential` to authenticate.",
+			},
+			"branch": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("main"),
+				MarkdownDescription: "Branch that is cloned and pulled.",
+			},
+			"credential": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				MarkdownDescription: "Name of the credential used to authenticate, eg `ansible-forms_credential.git.name`: a user and a token for https, or a private key for ssh.",
+			},
+			"use_for_forms": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the forms configuration is read from the repository.",
+			},
+			"use_for_playbooks": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the playbooks are run from the repository.",
+			},
+			"cron": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				MarkdownDescription: "Cron expression of the periodic pull, eg `*/15 * * * *`. The repository is only pulled on demand when empty.",
+			},
+			"pull_on_apply": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Any value, the repository is pulled when it changes, eg a release tag, or `timestamp()` to pull on every apply. " +
+					"The repository is not pulled when the value is set on create, as it is cloned anyway.",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Status of the last clone or pull, eg `cloned`, `pulled`, or the error.",
+			},
+			"last_commit": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Commit of the last successful clone or pull.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *RepositoryResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Repository Resource Configure Type",
+			fmt.Sprintf("Expected Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	}
+	r.config.providerConfig = config
+}
+
+// repositoryBody returns the body of a repository request.
+func repositoryBody(data *RepositoryResourceModel) interfaces.RepositoryResourceModel {
+	return interfaces.RepositoryResourceModel{
+		Name:            data.Name.ValueString(),
+		URI:             data.URI.ValueString(),
+		Branch:          data.Branch.ValueString(),
+		Credential:      data.Credential.ValueString(),
+		UseForForms:     data.UseForForms.ValueBool(),
+		UseForPlaybooks: data.UseForPlaybooks.ValueBool(),
+		Cron:            data.Cron.ValueString(),
+	}
+}
+
+// setRepositoryResourceModel copies the server repository into data.
+func setRepositoryResourceModel(data *RepositoryResourceModel, repository *interfaces.RepositoryGetDataSourceModel) {
+	data.ID = types.StringValue(strconv.FormatInt(repository.ID, 10))
+	data.Name = types.StringValue(repository.Name)
+	data.URI = types.StringValue(repository.URI)
+	data.Branch = types.StringValue(repository.Branch)
+	data.Credential = types.StringValue(repository.Credential)
+	data.UseForForms = types.BoolValue(repository.UseForForms)
+	data.UseForPlaybooks = types.BoolValue(repository.UseForPlaybooks)
+	data.Cron = types.StringValue(repository.Cron)
+	data.Status = types.StringValue(repository.Status)
+	data.LastCommit = types.StringValue(repository.LastCommit)
+}
+
+// refresh reads the repository back after a change, for its status and last commit, and reports a warning when the
+// clone or pull failed.
+func (r *RepositoryResource) refresh(errorHandler *utils.ErrorHandler, diags *diag.Diagnostics, client restclient.Client, data *RepositoryResourceModel) {
+	repository, err := interfaces.GetRepositoryByID(errorHandler, client, data.ID.ValueString())
+	if err != nil {
+		return
+	}
+	if repository == nil {
+		errorHandler.MakeAndReportError("error reading repository", fmt.Sprintf("repository %s not found after it was saved", data.Name.ValueString()))
+		return
+	}
+	setRepositoryResourceModel(data, repository)
+	if strings.HasPrefix(repository.Status, "error") {
+		diags.AddWarning("repository not up to date",
+			fmt.Sprintf("Repository %s is saved, but Ansible Forms failed to clone or pull it: %s", repository.Name, repository.Status))
+	}
+}
+
+// Create a new resource, Ansible Forms clones the repository.
+func (r *RepositoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *RepositoryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the uri may hold a token
+	ctx = utils.ContextWithSecrets(ctx, interfaces.URISecrets(data.URI.ValueString())...)
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	id, err := interfaces.CreateRepository(errorHandler, client, repositoryBody(data))
+	if err != nil {
+		return
+	}
+	data.ID = types.StringValue(strconv.FormatInt(id, 10))
+	r.refresh(errorHandler, &resp.Diagnostics, client, data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("created a repository resource: %s, id %s, commit %s", data.Name.ValueString(), data.ID.ValueString(), data.LastCommit.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findRepository returns the repository of data, by id, or by name after an import.
+func (r *RepositoryResource) findRepository(errorHandler *utils.ErrorHandler, client restclient.Client, data *RepositoryResourceModel) (*interfaces.RepositoryGetDataSourceModel, error) {
+	if data.ID.IsNull() {
+		return interfaces.GetRepositoryByName(errorHandler, client, data.Name.ValueString())
+	}
+	return interfaces.GetRepositoryByID(errorHandler, client, data.ID.ValueString())
+}
+
+// Read resource information.
+func (r *RepositoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *RepositoryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the uri may hold a token, its user info is also redacted from the responses when it is not known after an import
+	ctx = utils.ContextWithSecrets(ctx, interfaces.URISecrets(data.URI.ValueString())...)
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	repository, err := r.findRepository(errorHandler, client, data)
+	if err != nil {
+		return
+	}
+	if repository == nil {
+		resp.Diagnostics.AddWarning("repository not found",
+			fmt.Sprintf("Repository %s no longer exists in Ansible Forms, it is removed from the state and will be created again on the next apply.", data.Name.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	setRepositoryResourceModel(data, repository)
+
+	tflog.Debug(ctx, fmt.Sprintf("read a repository resource: %s, id %s", data.Name.ValueString(), data.ID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates the repository, and pulls it when pull_on_apply changed.
+func (r *RepositoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *RepositoryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the uri may hold a token, the previous one is in the responses until the update
+	ctx = utils.ContextWithSecrets(ctx, append(interfaces.URISecrets(data.URI.ValueString()), interfaces.URISecrets(state.URI.ValueString())...)...)
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	if err = interfaces.UpdateRepository(errorHandler, client, data.ID.ValueString(), repositoryBody(data)); err != nil {
+		return
+	}
+	if !data.PullOnApply.Equal(state.PullOnApply) {
+		if err = interfaces.PullRepository(errorHandler, client, data.ID.ValueString()); err != nil {
+			return
+		}
+	}
+	r.refresh(errorHandler, &resp.Diagnostics, client, data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("updated a repository resource: %s, commit %s", data.Name.ValueString(), data.LastCommit.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes the repository.
+func (r *RepositoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *RepositoryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the uri may hold a token
+	ctx = utils.ContextWithSecrets(ctx, interfaces.URISecrets(data.URI.ValueString())...)
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	_ = interfaces.DeleteRepositoryByID(errorHandler, client, data.ID.ValueString())
+}
+
+// ImportState imports a repository with an identifier in the format name,cx_profile_name.
+func (r *RepositoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Debug(ctx, fmt.Sprintf("import req a repository resource: %#v", req))
+	// repository names may contain commas, the connection profile name is after the last one
+	index := strings.LastIndex(req.ID, ",")
+	if index <= 0 || index == len(req.ID)-1 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: name,cx_profile_name. Got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID[:index])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cx_profile_name"), req.ID[index+1:])...)
+}
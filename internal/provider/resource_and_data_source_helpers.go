@@ -3,8 +3,12 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 
@@ -13,13 +17,13 @@ import (
 )
 
 type resourceOrDataSourceConfig struct {
-	client         *restclient.RestClient
+	client         restclient.Client
 	providerConfig Config
 	name           string
 }
 
 // getRestClient will use existing client config.client or create one if it's not set
-func getRestClient(errorHandler *utils.ErrorHandler, config resourceOrDataSourceConfig, cxProfileName types.String) (*restclient.RestClient, error) {
+func getRestClient(errorHandler *utils.ErrorHandler, config resourceOrDataSourceConfig, cxProfileName types.String) (restclient.Client, error) {
 
 	if config.client == nil {
 		client, err := config.providerConfig.NewClient(errorHandler, cxProfileName.ValueString(), config.name)
@@ -63,7 +67,7 @@ func jsonStringToMapValue(ctx context.Context, diags *diag.Diagnostics, str stri
 	err := json.Unmarshal([]byte(str), &credentialsMap)
 	if err != nil {
 		diags.AddError("error unmarshalling JSON string", err.Error())
-		return basetypes.MapValue{}
+		return types.MapNull(types.StringType)
 	}
 
 	for k, v := range credentialsMap {
@@ -79,3 +83,51 @@ func jsonStringToMapValue(ctx context.Context, diags *diag.Diagnostics, str stri
 
 	return m
 }
+
+// anyToJSONStringValue returns value encoded as a JSON string, or null when value is nil.
+func anyToJSONStringValue(diags *diag.Diagnostics, value any) types.String {
+	if value == nil {
+		return types.StringNull()
+	}
+	document, err := json.Marshal(value)
+	if err != nil {
+		diags.AddError("error marshalling JSON value", err.Error())
+		return types.StringNull()
+	}
+
+	return types.StringValue(string(document))
+}
+
+// writeOnlySecretAttributes returns the schema of the write-only secret name, described by what, eg "SMTP password",
+// and of name_version, the trigger to send the secret again.
+//
+// Ansible Forms never returns these secrets, and keeps the current one when an update request does not include it: the
+// request models of the interfaces package omit an empty secret, so that it is only sent on create and when
+// name_version changes, see readWriteOnlyIfVersionChanged.
+func writeOnlySecretAttributes(name string, what string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		name: schema.StringAttribute{
+			Optional:  true,
+			Sensitive: true,
+			WriteOnly: true,
+			MarkdownDescription: fmt.Sprintf("%s, never stored in the state. It is sent when the resource is created, and when `%s_version` changes. ", what, name) +
+				"Requires Terraform 1.11 or later.",
+		},
+		name + "_version": schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("Change this value to send `%s` again, eg after a rotation.", name),
+		},
+	}
+}
+
+// readWriteOnlyIfVersionChanged returns the write-only secret at attributePath in config when planVersion differs from
+// stateVersion, and null otherwise, so that the secret is only sent again when its version changes.
+func readWriteOnlyIfVersionChanged(ctx context.Context, config tfsdk.Config, attributePath path.Path, planVersion types.Int64, stateVersion types.Int64) (types.String, diag.Diagnostics) {
+	secret := types.StringNull()
+	if planVersion.Equal(stateVersion) {
+		return secret, nil
+	}
+	diags := config.GetAttribute(ctx, attributePath, &secret)
+
+	return secret, diags
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestReadWriteOnlyIfVersionChanged(t *testing.T) {
+	ctx := context.Background()
+	configSchema := schema.Schema{Attributes: writeOnlySecretAttributes("password", "Password")}
+	config := tfsdk.Config{Schema: configSchema, Raw: tftypes.NewValue(configSchema.Type().TerraformType(ctx), map[string]tftypes.Value{
+		"password":         tftypes.NewValue(tftypes.String, "s3cr3t"),
+		"password_version": tftypes.NewValue(tftypes.Number, 2),
+	})}
+	tests := []struct {
+		name         string
+		planVersion  types.Int64
+		stateVersion types.Int64
+		want         types.String
+	}{
+		{name: "unchanged", planVersion: types.Int64Value(2), stateVersion: types.Int64Value(2), want: types.StringNull()},
+		{name: "changed", planVersion: types.Int64Value(2), stateVersion: types.Int64Value(1), want: types.StringValue("s3cr3t")},
+		// after an import, the version is not in the state
+		{name: "imported", planVersion: types.Int64Value(2), stateVersion: types.Int64Null(), want: types.StringValue("s3cr3t")},
+		{name: "unset", planVersion: types.Int64Null(), stateVersion: types.Int64Null(), want: types.StringNull()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := readWriteOnlyIfVersionChanged(ctx, config, path.Root("password"), tt.planVersion, tt.stateVersion)
+			if diags.HasError() {
+				t.Fatalf("readWriteOnlyIfVersionChanged() diagnostics = %v", diags)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("readWriteOnlyIfVersionChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteOnlySecretAttributes(t *testing.T) {
+	attributes := writeOnlySecretAttributes("mail_password", "SMTP password")
+	secret, ok := attributes["mail_password"].(schema.StringAttribute)
+	if !ok || !secret.WriteOnly || !secret.Sensitive || !secret.Optional {
+		t.Errorf("writeOnlySecretAttributes() mail_password = %#v, want an optional, sensitive and write-only string", attributes["mail_password"])
+	}
+	version, ok := attributes["mail_password_version"].(schema.Int64Attribute)
+	if !ok || version.WriteOnly || !version.Optional {
+		t.Errorf("writeOnlySecretAttributes() mail_password_version = %#v, want an optional int64", attributes["mail_password_version"])
+	}
+}
@@ -0,0 +1,293 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/interfaces"
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &SettingsResource{}
+	_ resource.ResourceWithConfigure   = &SettingsResource{}
+	_ resource.ResourceWithImportState = &SettingsResource{}
+)
+
+// NewSettingsResource is a helper function to simplify the provider implementation.
+func NewSettingsResource() resource.Resource {
+	return &SettingsResource{
+		config: resourceOrDataSourceConfig{
+			name: "settings",
+		},
+	}
+}
+
+// SettingsResource is the resource implementation.
+type SettingsResource struct {
+	config resourceOrDataSourceConfig
+}
+
+// SettingsResourceModel maps the resource schema data.
+type SettingsResourceModel struct {
+	CxProfileName            types.String `tfsdk:"cx_profile_name"`
+	ID                       types.String `tfsdk:"id"`
+	URL                      types.String `tfsdk:"url"`
+	MailServer               types.String `tfsdk:"mail_server"`
+	MailPort                 types.Int64  `tfsdk:"mail_port"`
+	MailSecure               types.Bool   `tfsdk:"mail_secure"`
+	MailUsername             types.String `tfsdk:"mail_username"`
+	MailPassword             types.String `tfsdk:"mail_password"`
+	MailPasswordVersion      types.Int64  `tfsdk:"mail_password_version"`
+	MailFrom                 types.String `tfsdk:"mail_from"`
+	JobRetentionDays         types.Int64  `tfsdk:"job_retention_days"`
+	FormsBackupRetentionDays types.Int64  `tfsdk:"forms_backup_retention_days"`
+	UITitle                  types.String `tfsdk:"ui_title"`
+	UITheme                  types.String `tfsdk:"ui_theme"`
+}
+
+// Metadata returns the resource type name.
+func (r *SettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.config.name
+}
+
+// settingStringAttribute returns the schema of a string setting, that keeps its current value when it is not set.
+func settingStringAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+		MarkdownDescription: description,
+	}
+}
+
+// settingInt64Attribute returns the schema of a number setting, that keeps its current value when it is not set.
+func settingInt64Attribute(description string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional: true,
+		Computed: true,
+		PlanModifiers: []planmodifier.Int64{
+			int64planmodifier.UseStateForUnknown(),
+		},
+		MarkdownDescription: description,
+	}
+}
+
+// settingBoolAttribute returns the schema of a boolean setting, that keeps its current value when it is not set.
+func settingBoolAttribute(description string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		PlanModifiers: []planmodifier.Bool{
+			boolplanmodifier.UseStateForUnknown(),
+		},
+		MarkdownDescription: description,
+	}
+}
+
+// Schema defines the schema for the resource.
+func (r *SettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages the general settings of Ansible Forms. There is one set of settings per Ansible Forms instance. " +
+			"Only the settings set in the configuration are managed: the other settings keep their current value, and changes made to them outside of Terraform are not reported. " +
+			"The settings are left unchanged when the resource is destroyed.",
+
+		Attributes: map[string]schema.Attribute{
+			"cx_profile_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Connection profile name.",
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "Identifier of the settings, the connection profile name.",
+			},
+			"url":                         settingStringAttribute("Base URL of Ansible Forms, used in the links of the emails."),
+			"mail_server":                 settingStringAttribute("SMTP server host name."),
+			"mail_port":                   settingInt64Attribute("SMTP server port."),
+			"mail_secure":                 settingBoolAttribute("Whether the connection to the SMTP server uses TLS."),
+			"mail_username":               settingStringAttribute("SMTP user name, empty when the SMTP server does not require authentication."),
+			"mail_from":                   settingStringAttribute("Sender address of the emails."),
+			"job_retention_days":          settingInt64Attribute("Number of days jobs are kept, 0 to keep them forever."),
+			"forms_backup_retention_days": settingInt64Attribute("Number of days backups of the forms configuration are kept, 0 to keep them forever."),
+			"ui_title":                    settingStringAttribute("Title shown in the Ansible Forms UI."),
+			"ui_theme":                    settingStringAttribute("Theme of the Ansible Forms UI, eg `light` or `dark`."),
+		},
+	}
+	maps.Copy(resp.Schema.Attributes, writeOnlySecretAttributes("mail_password", "SMTP password"))
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *SettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Settings Resource Configure Type",
+			fmt.Sprintf("Expected Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	}
+	r.config.providerConfig = config
+}
+
+// settingsBody returns the settings set in config, with mailPassword unless it is null.
+func settingsBody(config *SettingsResourceModel, mailPassword types.String) interfaces.SettingsResourceModel {
+	body := interfaces.SettingsResourceModel{
+		URL:                      config.URL.ValueStringPointer(),
+		MailServer:               config.MailServer.ValueStringPointer(),
+		MailPort:                 config.MailPort.ValueInt64Pointer(),
+		MailSecure:               config.MailSecure.ValueBoolPointer(),
+		MailUsername:             config.MailUsername.ValueStringPointer(),
+		MailFrom:                 config.MailFrom.ValueStringPointer(),
+		JobRetentionDays:         config.JobRetentionDays.ValueInt64Pointer(),
+		FormsBackupRetentionDays: config.FormsBackupRetentionDays.ValueInt64Pointer(),
+		UITitle:                  config.UITitle.ValueStringPointer(),
+		UITheme:                  config.UITheme.ValueStringPointer(),
+	}
+	body.MailPassword = mailPassword.ValueStringPointer()
+	return body
+}
+
+// read copies all the settings into data, including the ones that are not managed.
+func (r *SettingsResource) read(errorHandler *utils.ErrorHandler, client restclient.Client, data *SettingsResourceModel) error {
+	settings, err := interfaces.GetSettings(errorHandler, client)
+	if err != nil {
+		return err
+	}
+	data.ID = data.CxProfileName
+	data.URL = types.StringValue(settings.URL)
+	data.MailServer = types.StringValue(settings.MailServer)
+	data.MailPort = types.Int64Value(settings.MailPort)
+	data.MailSecure = types.BoolValue(settings.MailSecure)
+	data.MailUsername = types.StringValue(settings.MailUsername)
+	data.MailFrom = types.StringValue(settings.MailFrom)
+	data.JobRetentionDays = types.Int64Value(settings.JobRetentionDays)
+	data.FormsBackupRetentionDays = types.Int64Value(settings.FormsBackupRetentionDays)
+	data.UITitle = types.StringValue(settings.UITitle)
+	data.UITheme = types.StringValue(settings.UITheme)
+	return nil
+}
+
+// apply saves the settings set in config and mailPassword, and reads all the settings back into data.
+func (r *SettingsResource) apply(errorHandler *utils.ErrorHandler, data *SettingsResourceModel, config *SettingsResourceModel, mailPassword types.String) {
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	if err = interfaces.UpdateSettings(errorHandler, client, settingsBody(config, mailPassword)); err != nil {
+		return
+	}
+	_ = r.read(errorHandler, client, data)
+}
+
+// Create saves the settings set in the configuration.
+func (r *SettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data, config *SettingsResourceModel
+
+	// Read Terraform plan data into the model, the configuration tells which settings are managed
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(utils.NewErrorHandler(ctx, &resp.Diagnostics), data, config, config.MailPassword)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("created a settings resource: %s", data.ID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read resource information.
+func (r *SettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *SettingsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	if err = r.read(errorHandler, client, data); err != nil {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("read a settings resource: %s", data.ID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update saves the settings set in the configuration, the mail password is only sent when mail_password_version changed.
+func (r *SettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, config, state *SettingsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	mailPassword, diags := readWriteOnlyIfVersionChanged(ctx, req.Config, path.Root("mail_password"), data.MailPasswordVersion, state.MailPasswordVersion)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(utils.NewErrorHandler(ctx, &resp.Diagnostics), data, config, mailPassword)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the resource from the state, the settings are left unchanged.
+func (r *SettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *SettingsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("settings resource removed from the state, the settings are left unchanged: %s", data.ID.ValueString()))
+}
+
+// ImportState imports the settings of the connection profile given as identifier.
+// The mail password is not imported, set mail_password_version to send it on the next apply.
+func (r *SettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", "Expected the connection profile name as import identifier.")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cx_profile_name"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
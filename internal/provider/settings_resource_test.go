@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccSettingsResource(t *testing.T) {
+	server := newTestAccServer(t)
+	if server.fake == nil {
+		t.Skip("the settings of a real server are not changed by acceptance tests")
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: server.providerConfig() + testAccSettingsResourceConfig("smtp.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ansible-forms_settings.settings", "id", "cluster4"),
+					resource.TestCheckResourceAttr("ansible-forms_settings.settings", "mail_server", "smtp.example.com"),
+					resource.TestCheckResourceAttr("ansible-forms_settings.settings", "mail_port", "587"),
+					// unmanaged settings are read
+					resource.TestCheckResourceAttr("ansible-forms_settings.settings", "ui_title", "Ansible Forms"),
+					testAccCheckFakeSetting(server, "log_level", "info")),
+			},
+			{
+				ResourceName:      "ansible-forms_settings.settings",
+				ImportState:       true,
+				ImportStateId:     "cluster4",
+				ImportStateVerify: true,
+			},
+			{
+				// a change to an unmanaged setting is not a difference
+				PreConfig: func() { server.fake.SetSetting("ui_theme", "dark") },
+				Config:    server.providerConfig() + testAccSettingsResourceConfig("smtp.example.com"),
+				PlanOnly:  true,
+			},
+			{
+				// a change to a managed setting is reverted, the unmanaged settings are kept
+				PreConfig: func() { server.fake.SetSetting("mail_server", "changed.example.com") },
+				Config:    server.providerConfig() + testAccSettingsResourceConfig("smtp.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ansible-forms_settings.settings", "ui_theme", "dark"),
+					testAccCheckFakeSetting(server, "mail_server", "smtp.example.com"),
+					testAccCheckFakeSetting(server, "ui_theme", "dark"),
+					testAccCheckFakeSetting(server, "log_level", "info")),
+			},
+		},
+	})
+}
+
+// testAccCheckFakeSetting checks a setting of the fake Ansible Forms server.
+func testAccCheckFakeSetting(server testAccServer, key string, want string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if value := server.fake.Settings()[key]; value != want {
+			return fmt.Errorf("setting %s = %v, want %s", key, value, want)
+		}
+		return nil
+	}
+}
+
+func testAccSettingsResourceConfig(mailServer string) string {
+	return fmt.Sprintf(`
+resource "ansible-forms_settings" "settings" {
+  cx_profile_name = "cluster4"
+  url             = "https://forms.example.com"
+  mail_server     = "%s"
+  mail_port       = 587
+  mail_secure     = true
+  mail_from       = "ansible-forms@example.com"
+}`, mailServer)
+}
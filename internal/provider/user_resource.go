@@ -0,0 +1,272 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-ansible-forms/internal/interfaces"
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &UserResource{}
+	_ resource.ResourceWithConfigure   = &UserResource{}
+	_ resource.ResourceWithImportState = &UserResource{}
+)
+
+// NewUserResource is a helper function to simplify the provider implementation.
+func NewUserResource() resource.Resource {
+	return &UserResource{
+		config: resourceOrDataSourceConfig{
+			name: "user",
+		},
+	}
+}
+
+// UserResource is the resource implementation.
+type UserResource struct {
+	config resourceOrDataSourceConfig
+}
+
+// UserResourceModel maps the resource schema data.
+type UserResourceModel struct {
+	CxProfileName   types.String `tfsdk:"cx_profile_name"`
+	Username        types.String `tfsdk:"username"`
+	ID              types.String `tfsdk:"id"`
+	Email           types.String `tfsdk:"email"`
+	Password        types.String `tfsdk:"password"`
+	PasswordVersion types.Int64  `tfsdk:"password_version"`
+	GroupID         types.String `tfsdk:"group_id"`
+}
+
+// Metadata returns the resource type name.
+func (r *UserResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.config.name
+}
+
+// Schema defines the schema for the resource.
+func (r *UserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a local user of Ansible Forms. " +
+			"Ansible Forms never returns the password, so changes to the password made outside of Terraform are not detected.",
+
+		Attributes: map[string]schema.Attribute{
+			"cx_profile_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Connection profile name.",
+			},
+			"username": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Username, unique in Ansible Forms.",
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "User identifier.",
+			},
+			"email": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				MarkdownDescription: "Email address of the user.",
+			},
+			"group_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Identifier of the local group the user belongs to, eg `ansible-forms_group.team.id`.",
+			},
+		},
+	}
+	maps.Copy(resp.Schema.Attributes, writeOnlySecretAttributes("password", "Password"))
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *UserResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	config, ok := req.ProviderData.(Config)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected User Resource Configure Type",
+			fmt.Sprintf("Expected Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+	}
+	r.config.providerConfig = config
+}
+
+// userBody returns the body of a user request, with password when it is not null.
+func userBody(diags *diag.Diagnostics, data *UserResourceModel, password types.String) interfaces.UserResourceModel {
+	groupID, err := strconv.ParseInt(data.GroupID.ValueString(), 10, 64)
+	if err != nil {
+		diags.AddAttributeError(path.Root("group_id"), "invalid group_id", fmt.Sprintf("group_id must be a group identifier: %s", err))
+	}
+	return interfaces.UserResourceModel{
+		Username: data.Username.ValueString(),
+		Email:    data.Email.ValueString(),
+		Password: password.ValueString(),
+		GroupID:  groupID,
+	}
+}
+
+// Create a new resource.
+func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *UserResourceModel
+	var password types.String
+
+	// Read Terraform plan data into the model, password is write-only so it is only present in the configuration
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	body := userBody(&resp.Diagnostics, data, password)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, err := interfaces.CreateUser(errorHandler, client, body)
+	if err != nil {
+		return
+	}
+	data.ID = types.StringValue(strconv.FormatInt(id, 10))
+
+	tflog.Trace(ctx, fmt.Sprintf("created a user resource: %s, id %s", data.Username.ValueString(), data.ID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findUser returns the user of data, by id, or by username after an import.
+func (r *UserResource) findUser(errorHandler *utils.ErrorHandler, client restclient.Client, data *UserResourceModel) (*interfaces.UserGetDataSourceModel, error) {
+	if data.ID.IsNull() {
+		return interfaces.GetUserByName(errorHandler, client, data.Username.ValueString())
+	}
+	return interfaces.GetUserByID(errorHandler, client, data.ID.ValueString())
+}
+
+// Read resource information.
+func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *UserResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	user, err := r.findUser(errorHandler, client, data)
+	if err != nil {
+		return
+	}
+	if user == nil {
+		resp.Diagnostics.AddWarning("user not found",
+			fmt.Sprintf("User %s no longer exists in Ansible Forms, it is removed from the state and will be created again on the next apply.", data.Username.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.ID = types.StringValue(strconv.FormatInt(user.ID, 10))
+	data.Username = types.StringValue(user.Username)
+	data.Email = types.StringValue(user.Email)
+	data.GroupID = types.StringValue(strconv.FormatInt(user.GroupID, 10))
+
+	tflog.Debug(ctx, fmt.Sprintf("read a user resource: %s, id %s", data.Username.ValueString(), data.ID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates the user, the password is only sent when password_version changed.
+func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *UserResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	password, diags := readWriteOnlyIfVersionChanged(ctx, req.Config, path.Root("password"), data.PasswordVersion, state.PasswordVersion)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	body := userBody(&resp.Diagnostics, data, password)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err = interfaces.UpdateUser(errorHandler, client, data.ID.ValueString(), body); err != nil {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes the user.
+func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *UserResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorHandler := utils.NewErrorHandler(ctx, &resp.Diagnostics)
+	client, err := getRestClient(errorHandler, r.config, data.CxProfileName)
+	if err != nil {
+		// error reporting done inside NewClient
+		return
+	}
+	_ = interfaces.DeleteUserByID(errorHandler, client, data.ID.ValueString())
+}
+
+// ImportState imports a user with an identifier in the format username,cx_profile_name.
+// The password is not imported, set password_version to send it on the next apply.
+func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Debug(ctx, fmt.Sprintf("import req a user resource: %#v", req))
+	// usernames may contain commas, the connection profile name is after the last one
+	index := strings.LastIndex(req.ID, ",")
+	if index <= 0 || index == len(req.ID)-1 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: username,cx_profile_name. Got: %q", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("username"), req.ID[:index])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cx_profile_name"), req.ID[index+1:])...)
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccUserResource(t *testing.T) {
+	server := newTestAccServer(t)
+	steps := []resource.TestStep{
+		{
+			Config: server.providerConfig() + testAccUserResourceConfig("jdoe@example.com", "storage"),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("ansible-forms_user.user", "id"),
+				resource.TestCheckResourceAttr("ansible-forms_user.user", "email", "jdoe@example.com"),
+				resource.TestCheckResourceAttrPair("ansible-forms_user.user", "group_id", "ansible-forms_group.storage", "id"),
+				resource.TestCheckNoResourceAttr("ansible-forms_user.user", "password")),
+		},
+		{
+			ResourceName:      "ansible-forms_user.user",
+			ImportState:       true,
+			ImportStateId:     "tf_jdoe,cluster4",
+			ImportStateVerify: true,
+		},
+		{
+			// the user moves to another group
+			Config: server.providerConfig() + testAccUserResourceConfig("john.doe@example.com", "network"),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("ansible-forms_user.user", "email", "john.doe@example.com"),
+				resource.TestCheckResourceAttrPair("ansible-forms_user.user", "group_id", "ansible-forms_group.network", "id")),
+		},
+	}
+	if server.fake != nil {
+		steps = append(steps, resource.TestStep{
+			// the user is changed outside of Terraform, the change is reverted and the password is kept
+			PreConfig: func() {
+				user, _ := server.fake.UserByName("tf_jdoe")
+				user.Email = "changed@example.com"
+				user.Password = "kept"
+				server.fake.ReplaceUser(user)
+			},
+			Config: server.providerConfig() + testAccUserResourceConfig("john.doe@example.com", "network"),
+			Check: func(_ *terraform.State) error {
+				user, _ := server.fake.UserByName("tf_jdoe")
+				if user.Email != "john.doe@example.com" || user.Password != "kept" {
+					return fmt.Errorf("user email = %s, password changed %v, want john.doe@example.com and the password to be kept", user.Email, user.Password != "kept")
+				}
+				return nil
+			},
+		})
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps:                    steps,
+	})
+}
+
+func testAccUserResourceConfig(email string, group string) string {
+	return fmt.Sprintf(`
+resource "ansible-forms_group" "storage" {
+  cx_profile_name = "cluster4"
+  name            = "tf_storage_users"
+}
+
+resource "ansible-forms_group" "network" {
+  cx_profile_name = "cluster4"
+  name            = "tf_network_users"
+}
+
+resource "ansible-forms_user" "user" {
+  cx_profile_name = "cluster4"
+  username        = "tf_jdoe"
+  email           = "%s"
+  group_id        = ansible-forms_group.%s.id
+}`, email, group)
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"gopkg.in/yaml.v3"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = yamlDocumentType{}
+	_ basetypes.StringValuableWithSemanticEquals = yamlDocumentValue{}
+)
+
+// yamlDocumentType is a string holding a YAML or JSON object.
+// Documents that decode to the same object are semantically equal, so that formatting changes do not cause a diff.
+type yamlDocumentType struct {
+	basetypes.StringType
+}
+
+// String returns a human readable string of the type name.
+func (t yamlDocumentType) String() string {
+	return "yamlDocumentType"
+}
+
+// ValueType returns the value type of the type.
+func (t yamlDocumentType) ValueType(_ context.Context) attr.Value {
+	return yamlDocumentValue{}
+}
+
+// Equal returns true if o is a yamlDocumentType.
+func (t yamlDocumentType) Equal(o attr.Type) bool {
+	_, ok := o.(yamlDocumentType)
+	return ok
+}
+
+// ValueFromString returns a yamlDocumentValue from a string value.
+func (t yamlDocumentType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return yamlDocumentValue{StringValue: in}, nil
+}
+
+// ValueFromTerraform returns a yamlDocumentValue from a Terraform value.
+func (t yamlDocumentType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := value.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", value)
+	}
+	return yamlDocumentValue{StringValue: stringValue}, nil
+}
+
+// yamlDocumentValue is a value of yamlDocumentType.
+type yamlDocumentValue struct {
+	basetypes.StringValue
+}
+
+// newYAMLDocumentValue returns a known yamlDocumentValue.
+func newYAMLDocumentValue(document string) yamlDocumentValue {
+	return yamlDocumentValue{StringValue: basetypes.NewStringValue(document)}
+}
+
+// Type returns yamlDocumentType.
+func (v yamlDocumentValue) Type(_ context.Context) attr.Type {
+	return yamlDocumentType{}
+}
+
+// Equal returns true if o is a yamlDocumentValue with the same string.
+func (v yamlDocumentValue) Equal(o attr.Value) bool {
+	other, ok := o.(yamlDocumentValue)
+	return ok && v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true when both documents decode to the same object.
+// Documents that cannot be decoded are only equal to the same string.
+func (v yamlDocumentValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(yamlDocumentValue)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. Please report this issue to the provider developers.", v, newValuable))
+		return false, diags
+	}
+	document, err := decodeYAMLDocument(v.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	newDocument, err := decodeYAMLDocument(newValue.ValueString())
+	if err != nil {
+		return false, diags
+	}
+
+	return reflect.DeepEqual(document, newDocument), diags
+}
+
+// decodeYAMLDocument decodes a YAML or JSON object, values use the JSON types.
+func decodeYAMLDocument(definition string) (map[string]any, error) {
+	var form map[string]any
+	if err := yaml.Unmarshal([]byte(definition), &form); err != nil {
+		return nil, err
+	}
+	if form == nil {
+		return nil, fmt.Errorf("the document must be an object")
+	}
+	// YAML and JSON decode numbers differently, a JSON round trip makes documents comparable with the server ones
+	document, err := json.Marshal(form)
+	if err != nil {
+		return nil, err
+	}
+	form = nil
+	err = json.Unmarshal(document, &form)
+
+	return form, err
+}
+
+// semanticallyEqual reports whether both documents decode to the same object.
+func (v yamlDocumentValue) semanticallyEqual(ctx context.Context, other yamlDocumentValue) bool {
+	if v.IsNull() || v.IsUnknown() || other.IsNull() || other.IsUnknown() {
+		return v.Equal(other)
+	}
+	equal, _ := v.StringSemanticEquals(ctx, other)
+	return equal
+}
+
+// normalizeYAMLDocument returns document as YAML, with sorted keys.
+func normalizeYAMLDocument(document map[string]any) (string, error) {
+	data, err := yaml.Marshal(document)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,7 @@
+package restclient
+
+// Exported for the tests of package restclient_test, that use restclienttest.
+var (
+	JobPollInterval = &jobPollInterval
+	Sleep           = sleep
+)
@@ -0,0 +1,221 @@
+package httpclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"terraform-provider-ansible-forms/internal/utils"
+)
+
+// Environment variables to record requests and responses to a cassette file, or to replay them from it.
+// Recording is meant to capture the behaviour of a real Ansible Forms server once, to run deterministic tests offline.
+const (
+	// CassetteModeEnv selects the mode, record or replay. Cassettes are not used when it is not set.
+	CassetteModeEnv = "ANSIBLE_FORMS_CASSETTE_MODE"
+	// CassetteEnv is the path of the cassette file.
+	CassetteEnv = "ANSIBLE_FORMS_CASSETTE"
+)
+
+// Cassette modes.
+const (
+	CassetteModeRecord = "record"
+	CassetteModeReplay = "replay"
+)
+
+// CassetteHostname replaces the server hostname in recorded responses.
+const CassetteHostname = "ansible-forms.example.com"
+
+// Interaction is a request and its response, as recorded in a cassette.
+// Sensitive values are redacted, and the Authorization header and the login request are never recorded.
+type Interaction struct {
+	Method string `json:"method"`
+	// Path is relative to the API root, and includes the query, eg job/12
+	Path        string `json:"path"`
+	RequestBody any    `json:"request_body,omitempty"`
+	// RequestBodyHash is the SHA-256 of the redacted request body, see requestBodyHash.
+	// It is part of the match key on replay, interactions recorded without it match any body.
+	RequestBodyHash string `json:"request_body_sha256,omitempty"`
+	StatusCode      int    `json:"status_code"`
+	// ResponseBody holds JSON responses, ResponseText any other response
+	ResponseBody json.RawMessage `json:"response_body,omitempty"`
+	ResponseText string          `json:"response_text,omitempty"`
+}
+
+// Cassette is the content of a cassette file.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// cassette is a cassette file shared by all the clients in the process.
+type cassette struct {
+	mutex    sync.Mutex
+	path     string
+	mode     string
+	content  Cassette
+	replayed []bool
+}
+
+// cassettes are loaded once per process, and shared by clients using the same path and mode.
+var cassettes = struct {
+	sync.Mutex
+	entries map[string]*cassette
+}{entries: map[string]*cassette{}}
+
+// cassetteFromEnvironment returns the cassette selected by CassetteModeEnv and CassetteEnv, or nil when cassettes are
+// not used.
+// In record mode, an existing cassette file is replaced the first time it is used by the process.
+func cassetteFromEnvironment() (*cassette, error) {
+	mode := os.Getenv(CassetteModeEnv)
+	if mode == "" {
+		return nil, nil
+	}
+	if mode != CassetteModeRecord && mode != CassetteModeReplay {
+		return nil, fmt.Errorf("invalid %s %q, expecting %s or %s", CassetteModeEnv, mode, CassetteModeRecord, CassetteModeReplay)
+	}
+	path := os.Getenv(CassetteEnv)
+	if path == "" {
+		return nil, fmt.Errorf("%s is required when %s is set", CassetteEnv, CassetteModeEnv)
+	}
+
+	cassettes.Lock()
+	defer cassettes.Unlock()
+	key := mode + "\x00" + path
+	if c, ok := cassettes.entries[key]; ok {
+		return c, nil
+	}
+	c := &cassette{path: path, mode: mode}
+	if mode == CassetteModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read cassette: %w", err)
+		}
+		if err = json.Unmarshal(data, &c.content); err != nil {
+			return nil, fmt.Errorf("unable to decode cassette %s: %w", path, err)
+		}
+		c.replayed = make([]bool, len(c.content.Interactions))
+	} else if err := c.save(); err != nil {
+		return nil, err
+	}
+	cassettes.entries[key] = c
+
+	return c, nil
+}
+
+// record adds an interaction to the cassette, and saves it.
+// Bodies are redacted with utils.Redactor.Shape so that they can be decoded on replay, and the hostname is replaced
+// with CassetteHostname.
+func (c *cassette) record(redactor *utils.Redactor, hostname string, method string, path string, requestBody map[string]any, statusCode int, responseBody []byte) error {
+	interaction := Interaction{
+		Method:     method,
+		Path:       path,
+		StatusCode: statusCode,
+	}
+	if len(requestBody) != 0 {
+		interaction.RequestBody = redactor.Shape(requestBody)
+		hash, err := requestBodyHash(redactor, requestBody)
+		if err != nil {
+			return err
+		}
+		interaction.RequestBodyHash = hash
+	}
+	var document any
+	if err := json.Unmarshal(responseBody, &document); err == nil {
+		redacted, err := json.Marshal(redactor.Shape(document))
+		if err != nil {
+			return err
+		}
+		interaction.ResponseBody = json.RawMessage(sanitizeHostname(string(redacted), hostname))
+	} else {
+		interaction.ResponseText = sanitizeHostname(redactor.String(string(responseBody)), hostname)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.content.Interactions = append(c.content.Interactions, interaction)
+
+	return c.save()
+}
+
+// replay returns the first interaction for method, path and bodyHash that was not replayed yet.
+// When they were all replayed, the last one is returned again, as Terraform may refresh a resource more often than
+// when the cassette was recorded.
+func (c *cassette) replay(method string, path string, bodyHash string) (Interaction, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	last := -1
+	for index, interaction := range c.content.Interactions {
+		if interaction.Method != method || interaction.Path != path {
+			continue
+		}
+		if interaction.RequestBodyHash != "" && interaction.RequestBodyHash != bodyHash {
+			continue
+		}
+		if !c.replayed[index] {
+			c.replayed[index] = true
+			return interaction, nil
+		}
+		last = index
+	}
+	if last == -1 {
+		return Interaction{}, fmt.Errorf("no interaction recorded for %s %s with body sha256 %q in cassette %s", method, path, bodyHash, c.path)
+	}
+
+	return c.content.Interactions[last], nil
+}
+
+// requestBodyHash returns the hex encoded SHA-256 of the JSON representation of the redacted request body, or an
+// empty string when there is no body. Secrets are redacted before hashing, so that a request replayed with other
+// credentials still matches. encoding/json sorts map keys, so the hash does not depend on their order.
+func requestBodyHash(redactor *utils.Redactor, body map[string]any) (string, error) {
+	if len(body) == 0 {
+		return "", nil
+	}
+	document, err := json.Marshal(redactor.Shape(body))
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(document)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// body returns the recorded response body.
+func (i Interaction) body() []byte {
+	if len(i.ResponseBody) != 0 {
+		return i.ResponseBody
+	}
+	return []byte(i.ResponseText)
+}
+
+// save writes the cassette file, the caller holds the mutex unless the cassette is not shared yet.
+func (c *cassette) save() error {
+	data, err := json.MarshalIndent(c.content, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(c.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("unable to write cassette: %w", err)
+	}
+	return nil
+}
+
+// sanitizeHostname replaces hostname with CassetteHostname in document.
+func sanitizeHostname(document string, hostname string) string {
+	if hostname == "" {
+		return document
+	}
+	return strings.ReplaceAll(document, hostname, CassetteHostname)
+}
+
+// cassettePath returns the path of a request relative to the API root, with the query.
+func cassettePath(baseURL string, req *Request) string {
+	path := strings.TrimPrefix(baseURL, "/")
+	if len(req.Query) != 0 {
+		path += "?" + req.Query.Encode()
+	}
+	return path
+}
@@ -0,0 +1,123 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHTTPClient_cassette(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/auth/login":
+			_, _ = w.Write([]byte(`{"token":"secret-token"}`))
+		case "/api/v1/credential/1":
+			_, _ = w.Write([]byte(`{"status":"success","message":"","data":{"output":{"name":"ontap","password":"ontap-pass","host":"` + r.Host + `"},"error":""}}`))
+		case "/api/v1/job/12":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`not found`))
+		default:
+			_, _ = w.Write([]byte(`{"status":"success","message":"job launched","data":{"output":{"id":12},"error":""}}`))
+		}
+	}))
+	hostname := strings.TrimPrefix(server.URL, "https://")
+	profile := HTTPProfile{APIRoot: "api/v1", Hostname: hostname, Username: "admin", Password: "admin-pass"}
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	requests := []struct {
+		baseURL string
+		req     *Request
+		want    int
+	}{
+		{baseURL: "credential/1", req: &Request{Method: "GET"}, want: http.StatusOK},
+		{baseURL: "job/", req: &Request{Method: "POST", Body: map[string]any{"formName": "Demo", "credentials": map[string]any{"ontap": "ontap"}}}, want: http.StatusOK},
+		{baseURL: "job/12", req: &Request{Method: "GET"}, want: http.StatusNotFound},
+	}
+
+	// record
+	t.Setenv(CassetteModeEnv, CassetteModeRecord)
+	t.Setenv(CassetteEnv, path)
+	client := NewClient(profile, "tag")
+	recorded := make([][]byte, len(requests))
+	for index, request := range requests {
+		statusCode, body, err := client.Do(context.Background(), request.baseURL, request.req)
+		if err != nil || statusCode != request.want {
+			t.Fatalf("record %s: statusCode = %d, err = %v", request.baseURL, statusCode, err)
+		}
+		recorded[index] = body
+	}
+	server.Close()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"secret-token", "ontap-pass", "admin-pass", hostname, "Authorization", "auth/login"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q: %s", secret, data)
+		}
+	}
+	for _, value := range []string{CassetteHostname, `"ontap": "***REDACTED***"`} {
+		if !strings.Contains(string(data), value) {
+			t.Errorf("cassette does not contain %q: %s", value, data)
+		}
+	}
+
+	// replay, the server is closed
+	t.Setenv(CassetteModeEnv, CassetteModeReplay)
+	client = NewClient(profile, "tag")
+	for _, repeat := range []int{1, 2} {
+		for index, request := range requests {
+			statusCode, body, err := client.Do(context.Background(), request.baseURL, request.req)
+			if err != nil || statusCode != request.want {
+				t.Fatalf("replay %d %s: statusCode = %d, err = %v", repeat, request.baseURL, statusCode, err)
+			}
+			if request.baseURL != "credential/1" && !equalJSON(body, recorded[index]) {
+				t.Errorf("replay %d %s: body = %s, want %s", repeat, request.baseURL, body, recorded[index])
+			}
+		}
+	}
+	if _, _, err = client.Do(context.Background(), "job/13", &Request{Method: "GET"}); err == nil {
+		t.Errorf("replay job/13: error = nil, want an error for a request that was not recorded")
+	}
+	// the body is part of the match key
+	if _, _, err = client.Do(context.Background(), "job/", &Request{Method: "POST", Body: map[string]any{"formName": "Other"}}); err == nil {
+		t.Errorf("replay job/: error = nil, want an error for a body that was not recorded")
+	}
+}
+
+func TestHTTPClient_cassetteConfiguration(t *testing.T) {
+	tests := []struct {
+		name string
+		mode string
+		path string
+	}{
+		{name: "invalid_mode", mode: "rewind", path: "cassette.json"},
+		{name: "missing_path", mode: CassetteModeRecord},
+		{name: "missing_file", mode: CassetteModeReplay, path: filepath.Join(t.TempDir(), "missing.json")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(CassetteModeEnv, tt.mode)
+			t.Setenv(CassetteEnv, tt.path)
+			client := NewClient(HTTPProfile{APIRoot: "api/v1", Hostname: "localhost"}, "tag")
+			if statusCode, _, err := client.Do(context.Background(), "job/", &Request{Method: "GET"}); err == nil || statusCode != -1 {
+				t.Errorf("Do() statusCode = %d, err = %v, want -1 and an error", statusCode, err)
+			}
+		})
+	}
+}
+
+// equalJSON reports whether two documents are equal, ignoring formatting. Other documents are compared as text.
+func equalJSON(got []byte, want []byte) bool {
+	var gotValue, wantValue any
+	if json.Unmarshal(got, &gotValue) != nil || json.Unmarshal(want, &wantValue) != nil {
+		return string(got) == string(want)
+	}
+	return reflect.DeepEqual(gotValue, wantValue)
+}
@@ -6,18 +6,25 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"golang.org/x/exp/slog"
+	"golang.org/x/net/http/httpproxy"
+
+	"terraform-provider-ansible-forms/internal/utils"
 )
 
 // HTTPClient represents a client for interaction with an Ansible Forms REST API
 type HTTPClient struct {
 	cxProfile  HTTPProfile
-	ctx        context.Context
 	httpClient http.Client
+	redactor   *utils.Redactor
 	tag        string
+	// cassette is set when requests are recorded or replayed, see CassetteModeEnv
+	cassette    *cassette
+	cassetteErr error
 }
 
 // HTTPProfile defines the connection attributes to build the base URL and authentication header
@@ -26,17 +33,27 @@ type HTTPProfile struct {
 	Hostname      string
 	Username      string
 	Password      string
+	Token         string
 	ValidateCerts bool
+	// ProxyURL is used for every request when set, otherwise HTTPS_PROXY and HTTP_PROXY apply.
+	// http, https, socks5 and socks5h schemes are supported, user info in the URL is used for proxy authentication.
+	ProxyURL string
+	// NoProxy is a comma separated list of hosts that bypass the proxy, it overrides NO_PROXY when set.
+	NoProxy       string
+	SensitiveKeys []string
+	// Secrets lists values to mask in logs in addition to Password and Token, eg the password of ProxyURL.
+	Secrets []string
 }
 
 // NewClient creates a new HTTP client
-func NewClient(ctx context.Context, cxProfile HTTPProfile, tag string) HTTPClient {
+func NewClient(cxProfile HTTPProfile, tag string) HTTPClient {
 	client := HTTPClient{
 		cxProfile: cxProfile,
-		ctx:       ctx,
+		redactor:  utils.NewRedactor(cxProfile.SensitiveKeys, append([]string{cxProfile.Password, cxProfile.Token}, cxProfile.Secrets...)...),
 		tag:       tag,
 	}
 	client.httpClient = client.create()
+	client.cassette, client.cassetteErr = cassetteFromEnvironment()
 
 	return client
 }
@@ -49,19 +66,31 @@ func NewClient(ctx context.Context, cxProfile HTTPProfile, tag string) HTTPClien
 //		failed to send HTTP request - statusCode forced to -1 unless it is present in the response
 //		failed to read HTTP response body - statusCode from response if present, otherwise -1
 //		empty response body (check with POST/PATCH/DELETE if this is really a problem)  - statusCode from response if present, otherwise -1
-func (c *HTTPClient) Do(baseURL string, req *Request) (int, []byte, error) {
-	httpReq, err := req.BuildHTTPReq(c, baseURL)
+//
+// The request, including the login to get a token, is canceled when ctx is done.
+// In replay mode, the response is read from the cassette and no request is sent.
+func (c *HTTPClient) Do(ctx context.Context, baseURL string, req *Request) (int, []byte, error) {
 	statusCode := -1
+	if c.cassetteErr != nil {
+		return statusCode, nil, c.cassetteErr
+	}
+	// secrets registered with utils.ContextWithSecrets are masked in addition to the profile secrets
+	redactor := c.redactor.ForContext(ctx)
+	ctx = redactor.Context(ctx)
+	if c.cassette != nil && c.cassette.mode == CassetteModeReplay {
+		return c.replay(ctx, redactor, baseURL, req)
+	}
+	httpReq, err := req.BuildHTTPReq(ctx, c, baseURL)
 	if err != nil {
 		return statusCode, nil, err
 	}
-	tflog.Debug(c.ctx, fmt.Sprintf("sending: %s %s", httpReq.Method, httpReq.URL.String()), map[string]any{"body": req.Body})
+	tflog.Debug(ctx, fmt.Sprintf("sending: %s %s", httpReq.Method, httpReq.URL.String()), map[string]any{"body": redactor.Value(req.Body)})
 	httpRes, err := c.httpClient.Do(httpReq)
 	if httpRes != nil {
 		statusCode = httpRes.StatusCode
 	}
 	if err != nil {
-		tflog.Error(c.ctx, fmt.Sprintf("HTTP request failed: %s, statusCode: %d, err raw:%#v", err, statusCode, err))
+		tflog.Error(ctx, fmt.Sprintf("HTTP request failed: %s, statusCode: %d, err raw:%#v", err, statusCode, err))
 		return statusCode, nil, err
 	}
 
@@ -74,7 +103,7 @@ func (c *HTTPClient) Do(baseURL string, req *Request) (int, []byte, error) {
 
 	body, err := io.ReadAll(httpRes.Body)
 	if err != nil {
-		tflog.Error(c.ctx, fmt.Sprintf("HTTP response read failed: %s, statusCode: %d", err, statusCode))
+		tflog.Error(ctx, fmt.Sprintf("HTTP response read failed: %s, statusCode: %d", err, statusCode))
 		return statusCode, nil, err
 	}
 
@@ -82,16 +111,61 @@ func (c *HTTPClient) Do(baseURL string, req *Request) (int, []byte, error) {
 		return httpRes.StatusCode, nil, fmt.Errorf("no result returned in REST response.  statusCode %d", statusCode)
 	}
 
-	tflog.Debug(c.ctx, fmt.Sprintf("received: %s %s %d", req.Method, httpReq.URL.String(), statusCode), map[string]any{"res": string(body)})
+	tflog.Debug(ctx, fmt.Sprintf("received: %s %s %d", req.Method, httpReq.URL.String(), statusCode), map[string]any{"res": redactor.JSON(body)})
+
+	if c.cassette != nil {
+		if err = c.cassette.record(redactor, c.cxProfile.Hostname, req.Method, cassettePath(baseURL, req), req.Body, statusCode, body); err != nil {
+			tflog.Error(ctx, fmt.Sprintf("unable to record %s %s: %s", req.Method, baseURL, err))
+			return statusCode, nil, err
+		}
+	}
 
 	return httpRes.StatusCode, body, nil
 }
 
+// replay returns the response recorded in the cassette for the request, matching its method, path and body.
+func (c *HTTPClient) replay(ctx context.Context, redactor *utils.Redactor, baseURL string, req *Request) (int, []byte, error) {
+	path := cassettePath(baseURL, req)
+	bodyHash, err := requestBodyHash(redactor, req.Body)
+	if err != nil {
+		return -1, nil, err
+	}
+	interaction, err := c.cassette.replay(req.Method, path, bodyHash)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		return -1, nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf("replayed: %s %s %d", req.Method, path, interaction.StatusCode), map[string]any{"res": string(interaction.body())})
+
+	return interaction.StatusCode, interaction.body(), nil
+}
+
 // create configures and creates the http client
+// Each client has its own transport, so that certificate validation and proxy settings only apply to its profile.
 func (c *HTTPClient) create() http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
 	if !c.cxProfile.ValidateCerts {
-		http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		// #nosec G402 -- certificate validation is disabled on request, with validate_certs = false.
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	proxyFunc := c.proxyConfig().ProxyFunc()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+
+	return http.Client{Timeout: 120 * time.Second, Transport: transport}
+}
+
+// proxyConfig merges the profile proxy settings with the environment.
+func (c *HTTPClient) proxyConfig() *httpproxy.Config {
+	config := httpproxy.FromEnvironment()
+	if c.cxProfile.ProxyURL != "" {
+		config.HTTPProxy = c.cxProfile.ProxyURL
+		config.HTTPSProxy = c.cxProfile.ProxyURL
+	}
+	if c.cxProfile.NoProxy != "" {
+		config.NoProxy = c.cxProfile.NoProxy
 	}
 
-	return http.Client{Timeout: 120 * time.Second}
+	return config
 }
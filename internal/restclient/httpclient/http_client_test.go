@@ -2,8 +2,10 @@ package httpclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 )
@@ -75,10 +77,13 @@ func TestHTTPClient_Do(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &HTTPClient{
 				cxProfile:  tt.fields.cxProfile,
-				ctx:        tt.fields.ctx,
 				httpClient: tt.fields.httpClient,
 			}
-			got, got1, err := c.Do(tt.args.baseURL, tt.args.req)
+			ctx := tt.fields.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			got, got1, err := c.Do(ctx, tt.args.baseURL, tt.args.req)
 			if err != nil {
 				fmt.Printf("err: %s\n", err)
 			}
@@ -95,3 +100,91 @@ func TestHTTPClient_Do(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPClient_proxy(t *testing.T) {
+	tests := []struct {
+		name      string
+		cxProfile HTTPProfile
+		host      string
+		want      string
+	}{
+		{name: "http_proxy", cxProfile: HTTPProfile{ProxyURL: "http://proxy:3128"}, host: "forms.example.com", want: "http://proxy:3128"},
+		{name: "socks5_proxy", cxProfile: HTTPProfile{ProxyURL: "socks5://proxy:1080"}, host: "forms.example.com", want: "socks5://proxy:1080"},
+		{name: "no_proxy_domain", cxProfile: HTTPProfile{ProxyURL: "http://proxy:3128", NoProxy: "other.com,.example.com"}, host: "forms.example.com", want: ""},
+		{name: "no_proxy_other", cxProfile: HTTPProfile{ProxyURL: "http://proxy:3128", NoProxy: "other.com"}, host: "forms.example.com", want: "http://proxy:3128"},
+	}
+	t.Setenv("HTTPS_PROXY", "")
+	t.Setenv("NO_PROXY", "")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient(tt.cxProfile, "test")
+			transport, ok := c.httpClient.Transport.(*http.Transport)
+			if !ok {
+				t.Fatalf("HTTPClient transport is %T, expecting *http.Transport", c.httpClient.Transport)
+			}
+			req, err := http.NewRequest(http.MethodGet, "https://"+tt.host+"/api/v1/job", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := transport.Proxy(req)
+			if err != nil {
+				t.Fatalf("Proxy() error = %v", err)
+			}
+			if (got == nil && tt.want != "") || (got != nil && got.String() != tt.want) {
+				t.Errorf("Proxy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPClient_proxyConnectOnLogin(t *testing.T) {
+	proxyAuthorization := make(chan string, 1)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodConnect {
+			select {
+			case proxyAuthorization <- r.Header.Get("Proxy-Authorization"):
+			default:
+			}
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer proxy.Close()
+
+	c := NewClient(HTTPProfile{
+		APIRoot:  "api/v1",
+		Hostname: "forms.example.com",
+		ProxyURL: "http://user:pass@" + proxy.Listener.Addr().String(),
+	}, "test")
+	if _, _, err := c.Do(context.Background(), "job", &Request{Method: "GET"}); err == nil {
+		t.Fatal("HTTPClient.Do() expected an error as the proxy refuses the tunnel")
+	}
+	select {
+	case got := <-proxyAuthorization:
+		if want := "Basic dXNlcjpwYXNz"; got != want {
+			t.Errorf("Proxy-Authorization = %s, want %s", got, want)
+		}
+	default:
+		t.Error("login request did not go through the proxy")
+	}
+}
+
+func TestHTTPClient_Do_canceled(t *testing.T) {
+	called := false
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	c := NewClient(HTTPProfile{
+		APIRoot:  "api/v1",
+		Hostname: server.Listener.Addr().String(),
+	}, "test")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := c.Do(ctx, "job", &Request{Method: "GET"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("HTTPClient.Do() error = %v, want %v", err, context.Canceled)
+	}
+	if called {
+		t.Error("HTTPClient.Do() sent a request with a canceled context")
+	}
+}
@@ -2,6 +2,7 @@ package httpclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -20,7 +21,7 @@ type Request struct {
 }
 
 // BuildHTTPReq builds an HTTP request to carry out the REST request
-func (r *Request) BuildHTTPReq(c *HTTPClient, baseURL string) (*http.Request, error) {
+func (r *Request) BuildHTTPReq(ctx context.Context, c *HTTPClient, baseURL string) (*http.Request, error) {
 	_url, err := r.BuildURL(c, baseURL, "")
 	if err != nil {
 		return nil, err
@@ -35,7 +36,7 @@ func (r *Request) BuildHTTPReq(c *HTTPClient, baseURL string) (*http.Request, er
 		}
 		body = bytes.NewReader(bodyJSON)
 	}
-	req, err = http.NewRequest(r.Method, _url, body)
+	req, err = http.NewRequestWithContext(ctx, r.Method, _url, body)
 
 	if err != nil {
 		return nil, err
@@ -44,7 +45,7 @@ func (r *Request) BuildHTTPReq(c *HTTPClient, baseURL string) (*http.Request, er
 	req.Header.Set("Content-Type", "application/json")
 	//req.SetBasicAuth(c.cxProfile.Username, c.cxProfile.Password)
 
-	token, err := r.getToken(c)
+	token, err := r.getToken(ctx, c)
 	if err != nil {
 		return nil, err
 	}
@@ -88,13 +89,17 @@ type authResponse struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
-func (r *Request) getToken(c *HTTPClient) (string, error) {
+// getToken returns the token from the profile if present, otherwise logs in with username and password.
+func (r *Request) getToken(ctx context.Context, c *HTTPClient) (string, error) {
+	if c.cxProfile.Token != "" {
+		return c.cxProfile.Token, nil
+	}
 	_url, err := r.BuildURL(c, "auth/login", "")
 	if err != nil {
 		return "", err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, _url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, _url, nil)
 	if err != nil {
 		return "", err
 	}
@@ -102,8 +107,8 @@ func (r *Request) getToken(c *HTTPClient) (string, error) {
 	req.Header.Set("Content-Type", "application/json")
 	req.SetBasicAuth(c.cxProfile.Username, c.cxProfile.Password)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	// use the profile client, so that proxy and certificate settings apply to the login as well
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return "", err
 	}
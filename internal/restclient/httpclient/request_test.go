@@ -67,7 +67,6 @@ func TestRequest_BuildHTTPReq(t *testing.T) {
 	}
 	client := &HTTPClient{
 		cxProfile: cxProfile,
-		ctx:       context.TODO(),
 	}
 	testURL := "https://host/api/cluster"
 	body := make(map[string]any)
@@ -110,7 +109,7 @@ func TestRequest_BuildHTTPReq(t *testing.T) {
 				Body:   tt.fields.Body,
 				Query:  tt.fields.Query,
 			}
-			got, err := r.BuildHTTPReq(tt.args.c, tt.args.baseURL)
+			got, err := r.BuildHTTPReq(context.Background(), tt.args.c, tt.args.baseURL)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Request.BuildHTTPReq() error = %v, wantErr %v", err, tt.wantErr)
 				return
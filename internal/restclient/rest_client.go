@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"reflect"
 	"strings"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/mitchellh/mapstructure"
 
 	"terraform-provider-ansible-forms/internal/restclient/httpclient"
+	"terraform-provider-ansible-forms/internal/utils"
 )
 
 // ConnectionProfile describes out to reach a cluster or svm.
@@ -21,29 +23,57 @@ type ConnectionProfile struct {
 	Hostname              string
 	Username              string
 	Password              string
+	Token                 string
 	ValidateCerts         bool
+	ProxyURL              string
+	NoProxy               string
 	MaxConcurrentRequests int
+	// SensitiveKeys lists JSON keys to redact from logs, in addition to utils.DefaultSensitiveKeys.
+	SensitiveKeys []string
+	// Secrets lists values to mask in logs in addition to Password and Token, eg the password of ProxyURL.
+	Secrets []string
 }
 
+// Client is the interface to the Ansible Forms REST API, as used by interfaces, resources and data sources.
+// It is implemented by RestClient.
+type Client interface {
+	CallCreateMethod(ctx context.Context, baseURL string, query *RestQuery, body map[string]any) (int, RestResponse, error)
+	CallUpdateMethod(ctx context.Context, baseURL string, query *RestQuery, body map[string]any) (int, RestResponse, error)
+	CallReplaceMethod(ctx context.Context, baseURL string, query *RestQuery, body map[string]any) (int, RestResponse, error)
+	CallDeleteMethod(ctx context.Context, baseURL string, query *RestQuery, body map[string]any) (int, RestResponse, error)
+	GetNilOrOneRecord(ctx context.Context, baseURL string, query *RestQuery, body map[string]any) (int, map[string]any, error)
+	GetZeroOrMoreRecords(ctx context.Context, baseURL string, query *RestQuery, body map[string]any) (int, []map[string]any, error)
+	Wait(ctx context.Context, id string) (int, RestResponse, error)
+	NewQuery() *RestQuery
+}
+
+// Transport sends a request and returns the decoded response.
+// RestClient uses HTTP unless a Transport is injected with SetTransport, eg restclienttest.MockTransport in Unit Testing.
+type Transport interface {
+	CallAPIMethod(ctx context.Context, method string, baseURL string, query *RestQuery, body map[string]any) (int, RestResponse, error)
+}
+
+var _ Client = &RestClient{}
+
 // RestClient to interact with the Ansible Forms REST API.
 type RestClient struct {
 	connectionProfile     ConnectionProfile
-	ctx                   context.Context
 	maxConcurrentRequests int
 	httpClient            httpclient.HTTPClient
+	redactor              *utils.Redactor
 	requestSlots          chan int
-	mode                  string
-	responses             []MockResponse
+	transport             Transport
 	jobCompletionTimeOut  int
 	tag                   string
 }
 
 // NewClient creates a new REST client and a supporting HTTP client.
+// ctx is only used for logging, each request uses the context of the operation.
 func NewClient(ctx context.Context, cxProfile ConnectionProfile, tag string, jobCompletionTimeOut int) (*RestClient, error) {
 	var httpProfile httpclient.HTTPProfile
 	err := mapstructure.Decode(cxProfile, &httpProfile)
 	if err != nil {
-		msg := fmt.Sprintf("decode error on ConnectionProfile %#v to HTTPProfile", cxProfile)
+		msg := fmt.Sprintf("decode error on ConnectionProfile for %s to HTTPProfile: %s", cxProfile.Hostname, err)
 		tflog.Error(ctx, msg)
 		return nil, errors.New(msg)
 	}
@@ -52,12 +82,12 @@ func NewClient(ctx context.Context, cxProfile ConnectionProfile, tag string, job
 	if maxConcurrentRequests == 0 {
 		maxConcurrentRequests = 6
 	}
+	redactor := utils.NewRedactor(cxProfile.SensitiveKeys, append([]string{cxProfile.Password, cxProfile.Token}, cxProfile.Secrets...)...)
 	client := RestClient{
 		connectionProfile:     cxProfile,
-		ctx:                   ctx,
-		httpClient:            httpclient.NewClient(ctx, httpProfile, tag),
+		httpClient:            httpclient.NewClient(httpProfile, tag),
 		maxConcurrentRequests: maxConcurrentRequests,
-		mode:                  "prod",
+		redactor:              redactor,
 		requestSlots:          make(chan int, maxConcurrentRequests),
 		jobCompletionTimeOut:  jobCompletionTimeOut,
 		tag:                   tag,
@@ -66,93 +96,67 @@ func NewClient(ctx context.Context, cxProfile ConnectionProfile, tag string, job
 	return &client, nil
 }
 
+// SetTransport sends the requests of the client through transport instead of HTTP.
+func (r *RestClient) SetTransport(transport Transport) {
+	r.transport = transport
+}
+
 // CallCreateMethod returns response from POST results.  An error is reported if an error is received.
-func (r *RestClient) CallCreateMethod(baseURL string, query *RestQuery, body map[string]any) (int, RestResponse, error) {
-	if query == nil {
-		query = r.NewQuery()
-	}
-	// TODO: make this a connection parameter ?
-	query.Set("return_timeout", "60")
-	statusCode, response, err := r.callAPIMethod("POST", baseURL, query, body)
+func (r *RestClient) CallCreateMethod(ctx context.Context, baseURL string, query *RestQuery, body map[string]any) (int, RestResponse, error) {
+	statusCode, response, err := r.callAPIMethod(ctx, "POST", baseURL, query, body)
 	if err != nil {
-		tflog.Debug(r.ctx, fmt.Sprintf("CallCreateMethod request failed %#v", statusCode))
+		tflog.Debug(ctx, fmt.Sprintf("CallCreateMethod request failed %#v", statusCode))
 		return statusCode, RestResponse{}, err
 	}
 
-	if response.Job != nil {
-		statusCode, _, err = r.Wait(response.Job["uuid"].(string))
-		if err != nil {
-			return statusCode, RestResponse{}, err
-		}
-	} else if response.Jobs != nil {
-		for _, v := range response.Jobs {
-			statusCode, _, err = r.Wait(v["uuid"].(string))
-			if err != nil {
-				return statusCode, RestResponse{}, err
-			}
-		}
-	}
-
 	return statusCode, response, err
 }
 
 // CallUpdateMethod returns response from PATCH results.  An error is reported if an error is received.
-func (r *RestClient) CallUpdateMethod(baseURL string, query *RestQuery, body map[string]any) (int, RestResponse, error) {
-	if query == nil {
-		query = r.NewQuery()
-	}
-	// TODO: make this a connection parameter ?
-	query.Set("return_timeout", "60")
-	statusCode, response, err := r.callAPIMethod("PATCH", baseURL, query, body)
+func (r *RestClient) CallUpdateMethod(ctx context.Context, baseURL string, query *RestQuery, body map[string]any) (int, RestResponse, error) {
+	statusCode, response, err := r.callAPIMethod(ctx, "PATCH", baseURL, query, body)
 	if err != nil {
-		tflog.Debug(r.ctx, fmt.Sprintf("CallUpdateMethod request failed %#v", statusCode))
+		tflog.Debug(ctx, fmt.Sprintf("CallUpdateMethod request failed %#v", statusCode))
 		return statusCode, RestResponse{}, err
 	}
 
-	if response.Job != nil {
-		statusCode, _, err = r.Wait(response.Job["uuid"].(string))
-		if err != nil {
-			return statusCode, RestResponse{}, err
-		}
-	} else if response.Jobs != nil {
-		for _, v := range response.Jobs {
-			statusCode, _, err = r.Wait(v["uuid"].(string))
-			if err != nil {
-				return statusCode, RestResponse{}, err
-			}
-		}
+	return statusCode, response, err
+}
+
+// CallReplaceMethod returns response from PUT results.  An error is reported if an error is received.
+// Ansible Forms has no PATCH endpoint, its objects and singletons such as config, settings or ldap are replaced by
+// the body of a PUT, so the body must be the whole object.
+func (r *RestClient) CallReplaceMethod(ctx context.Context, baseURL string, query *RestQuery, body map[string]any) (int, RestResponse, error) {
+	statusCode, response, err := r.callAPIMethod(ctx, "PUT", baseURL, query, body)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("CallReplaceMethod request failed %#v", statusCode))
+		return statusCode, RestResponse{}, err
 	}
 
 	return statusCode, response, err
 }
 
 // CallDeleteMethod returns response from DELETE results.  An error is reported if an error is received.
-func (r *RestClient) CallDeleteMethod(baseURL string, query *RestQuery, body map[string]any) (int, RestResponse, error) {
-	if query == nil {
-		query = r.NewQuery()
-	}
-	// TODO: make this a connection parameter ?
-	query.Set("return_timeout", "60")
-	statusCode, response, err := r.callAPIMethod("DELETE", baseURL, query, body)
+func (r *RestClient) CallDeleteMethod(ctx context.Context, baseURL string, query *RestQuery, body map[string]any) (int, RestResponse, error) {
+	statusCode, response, err := r.callAPIMethod(ctx, "DELETE", baseURL, query, body)
 	if err != nil {
-		tflog.Debug(r.ctx, fmt.Sprintf("CallDeleteMethod request failed %#v", statusCode))
+		tflog.Debug(ctx, fmt.Sprintf("CallDeleteMethod request failed %#v", statusCode))
 		return statusCode, RestResponse{}, err
 	}
 
-	// TODO: handle waitOnCompletion
 	return statusCode, response, err
 }
 
 // GetNilOrOneRecord returns nil if no record is found or a single record.  An error is reported if multiple records are received.
-func (r *RestClient) GetNilOrOneRecord(baseURL string, query *RestQuery, body map[string]any) (int, map[string]any, error) {
-	statusCode, response, err := r.callAPIMethod("GET", baseURL, query, body)
+func (r *RestClient) GetNilOrOneRecord(ctx context.Context, baseURL string, query *RestQuery, body map[string]any) (int, map[string]any, error) {
+	statusCode, response, err := r.callAPIMethod(ctx, "GET", baseURL, query, body)
 	if err != nil {
 		return statusCode, nil, err
 	}
 	if response.NumRecords > 1 {
-		msg := fmt.Sprintf("received 2 or more records when only one is expected - statusCode %d, err=%#v, response=%#v", statusCode, err, response)
-		tflog.Error(r.ctx, msg)
-		return statusCode, nil, errors.New(msg)
+		msg := fmt.Sprintf("received 2 or more records when only one is expected - statusCode %d, err=%#v, records=%v", statusCode, err, r.redactor.ForContext(ctx).Value(response.Records))
+		tflog.Error(ctx, msg)
+		return statusCode, nil, &RequestError{ErrorType: ErrorTypeUnexpectedRecordNum, StatusCode: statusCode, Method: "GET", Path: baseURL, Err: errors.New(msg)}
 	}
 	if response.NumRecords == 1 {
 		return statusCode, response.Records[0], err
@@ -162,8 +166,8 @@ func (r *RestClient) GetNilOrOneRecord(baseURL string, query *RestQuery, body ma
 }
 
 // GetZeroOrMoreRecords returns a list of records.
-func (r *RestClient) GetZeroOrMoreRecords(baseURL string, query *RestQuery, body map[string]any) (int, []map[string]any, error) {
-	statusCode, response, err := r.callAPIMethod("GET", baseURL, query, body)
+func (r *RestClient) GetZeroOrMoreRecords(ctx context.Context, baseURL string, query *RestQuery, body map[string]any) (int, []map[string]any, error) {
+	statusCode, response, err := r.callAPIMethod(ctx, "GET", baseURL, query, body)
 	if err != nil {
 		return statusCode, nil, err
 	}
@@ -171,55 +175,58 @@ func (r *RestClient) GetZeroOrMoreRecords(baseURL string, query *RestQuery, body
 	return statusCode, response.Records, err
 }
 
-// Wait waits for job to finish.
-func (r *RestClient) Wait(uuid string) (int, RestResponse, error) {
-	timeRemaining := r.jobCompletionTimeOut
+// jobPollInterval is the delay between two reads of a job status in Wait.
+var jobPollInterval = 10 * time.Second
+
+// Wait waits for an Ansible Forms job to finish, and returns the last response for the job.
+// A job waiting for approval is returned without error, as it cannot progress without a user action.
+// An error is returned if the job fails, is aborted or rejected, or does not finish within the job completion timeout.
+func (r *RestClient) Wait(ctx context.Context, id string) (int, RestResponse, error) {
+	timeRemaining := time.Duration(r.jobCompletionTimeOut) * time.Second
 	errorRetries := 3
-	for timeRemaining > 0 {
-		statusCode, response, err := r.GetNilOrOneRecord("job/"+uuid, nil, nil)
+	for {
+		statusCode, response, err := r.callAPIMethod(ctx, "GET", "job/"+id, nil, nil)
 		if err != nil {
-			if errorRetries <= 0 {
+			if errorRetries <= 0 || IsNotFound(err) {
 				return statusCode, RestResponse{}, err
 			}
-			time.Sleep(10 * time.Second)
 			errorRetries--
-			continue
-		}
-		var job Job
-		if err := mapstructure.Decode(response, &job); err != nil {
-			tflog.Error(r.ctx, fmt.Sprintf("Read job data - decode error: %s, data: %#v", err, response))
-			return statusCode, RestResponse{}, err
-		}
-		if job.State == "queued" || job.State == "running" || job.State == "paused" {
-			timeRemaining = timeRemaining - 10
-		} else if job.State == "success" {
-			return statusCode, RestResponse{}, nil
 		} else {
-			// if job struct ifself contains message and code, jobError struct might be empty. Vice versa.
-			if job.Error != (jobError{}) {
-				if job.Error.Code != "" {
-					errorMessage := fmt.Errorf("fail to get job status. Error code: %s. Message: %s, Target: %s", job.Error.Code, job.Error.Message, job.Error.Target)
-					return statusCode, RestResponse{}, errorMessage
-				}
-				return statusCode, RestResponse{}, fmt.Errorf("fail to get job status. Unknown error")
+			var job Job
+			if err = response.DecodeOutput(&job); err != nil {
+				tflog.Error(ctx, fmt.Sprintf("Read job data - decode error: %s, data: %v", err, r.redactor.ForContext(ctx).Value(response.Output)))
+				return statusCode, response, err
 			}
-			if job.Code != 0 {
-				return statusCode, RestResponse{}, fmt.Errorf("job UUID %s failed. Error code: %d. Message: %s", uuid, job.Code, job.Message)
+			switch job.Status {
+			case JobStatusRunning, JobStatusQueued, JobStatusAbortRequested, JobStatusApproved:
+			case JobStatusSuccess, JobStatusWaitingApproval:
+				return statusCode, response, nil
+			default:
+				return statusCode, response, fmt.Errorf("job %s did not succeed, status: %s, message: %s", id, job.Status, job.Message)
 			}
 		}
-		time.Sleep(10 * time.Second)
+		if timeRemaining <= 0 {
+			break
+		}
+		if err = sleep(ctx, jobPollInterval); err != nil {
+			return statusCode, response, err
+		}
+		timeRemaining -= jobPollInterval
 	}
 
-	// TODO: clean up the resources in creation when errors out.
-	return 0, RestResponse{}, fmt.Errorf("fail to wait for job to finish. Exit now")
+	return 0, RestResponse{}, fmt.Errorf("job %s did not finish within %d seconds", id, r.jobCompletionTimeOut)
 }
 
 // callAPIMethod can be used to make a request to any REST API method, receiving response as bytes.
-func (r *RestClient) callAPIMethod(method string, baseURL string, query *RestQuery, body map[string]any) (int, RestResponse, error) {
-	if r.mode == "mock" {
-		return r.mockCallAPIMethod(method, baseURL, query, body)
+func (r *RestClient) callAPIMethod(ctx context.Context, method string, baseURL string, query *RestQuery, body map[string]any) (int, RestResponse, error) {
+	ctx = r.redactor.Context(ctx)
+	if r.transport != nil {
+		statusCode, response, err := r.transport.CallAPIMethod(ctx, method, baseURL, query, body)
+		return statusCode, response, withRequest(err, method, baseURL)
+	}
+	if err := r.waitForAvailableSlot(ctx); err != nil {
+		return -1, RestResponse{}, err
 	}
-	r.waitForAvailableSlot()
 	defer r.releaseSlot()
 
 	values := url.Values{}
@@ -227,19 +234,38 @@ func (r *RestClient) callAPIMethod(method string, baseURL string, query *RestQue
 		values = query.Values
 	}
 
-	statusCode, response, httpClientErr := r.httpClient.Do(baseURL, &httpclient.Request{
+	statusCode, response, httpClientErr := r.httpClient.Do(ctx, baseURL, &httpclient.Request{
 		Method: method,
 		Body:   body,
 		Query:  values,
 	})
 
-	// TODO: error handling for HTTTP status code >=300
 	// TODO: handle async calls (job in response)
-	return r.unmarshalResponse(statusCode, response, httpClientErr)
+	statusCode, restResponse, err := r.unmarshalResponse(ctx, statusCode, response, httpClientErr)
+
+	return statusCode, restResponse, withRequest(err, method, baseURL)
+}
+
+// waitForAvailableSlot blocks until a request slot is available, or ctx is done.
+func (r *RestClient) waitForAvailableSlot(ctx context.Context) error {
+	select {
+	case r.requestSlots <- 1:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
-func (r *RestClient) waitForAvailableSlot() {
-	r.requestSlots <- 1
+// sleep pauses for duration, and returns early with an error when ctx is done.
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 func (r *RestClient) releaseSlot() {
@@ -248,7 +274,7 @@ func (r *RestClient) releaseSlot() {
 
 // Equals is a test function for Unit Testing
 func (r *RestClient) Equals(r2 *RestClient) (ok bool, firstDiff string) {
-	if r.connectionProfile != r2.connectionProfile {
+	if !reflect.DeepEqual(r.connectionProfile, r2.connectionProfile) {
 		return false, fmt.Sprintf("expected %#v, got %#v", r.connectionProfile, r2.connectionProfile)
 	}
 	if r.tag != r2.tag {
@@ -287,16 +313,22 @@ func (q *RestQuery) SetValues(keyValues map[string]any) {
 	}
 }
 
-// Job is Ansible Forms API job data structure
-type Job struct {
-	State   string
-	Error   jobError
-	Code    int
-	Message string
-}
+// Ansible Forms job statuses.
+const (
+	JobStatusQueued          = "queued"
+	JobStatusRunning         = "running"
+	JobStatusSuccess         = "success"
+	JobStatusFailed          = "failed"
+	JobStatusAbortRequested  = "abort"
+	JobStatusAborted         = "aborted"
+	JobStatusWaitingApproval = "approve"
+	JobStatusApproved        = "approved"
+	JobStatusRejected        = "rejected"
+)
 
-type jobError struct {
-	Message string `tfsdk:"state"`
-	Code    string `tfsdk:"code"`
-	Target  string `tfsdk:"target"`
+// Job is Ansible Forms API job data structure, as needed to wait for completion.
+type Job struct {
+	ID      int64  `mapstructure:"id"`
+	Status  string `mapstructure:"status"`
+	Message string `mapstructure:"message"`
 }
@@ -1,42 +1,48 @@
-package restclient
+package restclient_test
 
 import (
+	"context"
+	"errors"
 	"reflect"
 	"testing"
+	"time"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+	"terraform-provider-ansible-forms/internal/restclient/restclienttest"
 )
 
 func TestRestClient_GetNilOrOneRecord(t *testing.T) {
 	type args struct {
 		baseURL string
-		query   *RestQuery
+		query   *restclient.RestQuery
 		body    map[string]any
 	}
 	record := map[string]any{
 		"option": "value",
 	}
-	oneRecord := RestResponse{NumRecords: 1, Records: []map[string]any{record}}
-	twoRecords := RestResponse{NumRecords: 2, Records: []map[string]any{record, record}}
+	oneRecord := restclient.RestResponse{NumRecords: 1, Records: []map[string]any{record}}
+	twoRecords := restclient.RestResponse{NumRecords: 2, Records: []map[string]any{record, record}}
 
-	responses := map[string][]MockResponse{
+	responses := map[string][]restclienttest.MockResponse{
 		"test_no_records_1": {
-			{"GET", "cluster", 200, RestResponse{}, nil},
+			{ExpectedMethod: "GET", ExpectedURL: "cluster", StatusCode: 200, Response: restclient.RestResponse{}},
 		},
 		"test_no_records_2": {
-			{"GET", "cluster", 200, RestResponse{NumRecords: 0}, nil},
+			{ExpectedMethod: "GET", ExpectedURL: "cluster", StatusCode: 200, Response: restclient.RestResponse{NumRecords: 0}},
 		},
 		// "test_no_records_3": {
-		// 	{"GET", "cluster", 200, RestResponse{NumRecords: 1, Records: []map[string]any{}}, nil},
+		// 	{ExpectedMethod: "GET", ExpectedURL: "cluster", StatusCode: 200, Response: restclient.RestResponse{NumRecords: 1, Records: []map[string]any{}}},
 		// },
 		"test_one_record_1": {
-			{"GET", "cluster", 200, oneRecord, nil},
+			{ExpectedMethod: "GET", ExpectedURL: "cluster", StatusCode: 200, Response: oneRecord},
 		},
 		"test_two_records_1": {
-			{"GET", "cluster", 200, twoRecords, nil},
+			{ExpectedMethod: "GET", ExpectedURL: "cluster", StatusCode: 200, Response: twoRecords},
 		},
 	}
 	tests := []struct {
 		name      string
-		responses []MockResponse
+		responses []restclienttest.MockResponse
 		args      args
 		want      int
 		want1     map[string]any
@@ -50,11 +56,11 @@ func TestRestClient_GetNilOrOneRecord(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			c, err := NewMockedRestClient(tt.responses)
+			c, err := restclienttest.NewMockedRestClient(t, tt.responses)
 			if err != nil {
 				panic(err)
 			}
-			got, got1, err := c.GetNilOrOneRecord(tt.args.baseURL, tt.args.query, tt.args.body)
+			got, got1, err := c.GetNilOrOneRecord(context.Background(), tt.args.baseURL, tt.args.query, tt.args.body)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("RestClient.GetNilOrOneRecord() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -68,3 +74,100 @@ func TestRestClient_GetNilOrOneRecord(t *testing.T) {
 		})
 	}
 }
+
+func TestRestClient_CallUpdateAndReplaceMethod(t *testing.T) {
+	body := map[string]any{"name": "demo"}
+	tests := []struct {
+		name    string
+		method  string
+		call    func(c *restclient.RestClient) (int, restclient.RestResponse, error)
+		wantErr bool
+	}{
+		{name: "update_patches", method: "PATCH", call: func(c *restclient.RestClient) (int, restclient.RestResponse, error) {
+			return c.CallUpdateMethod(context.Background(), "user/7", nil, body)
+		}},
+		// Ansible Forms replaces objects, the whole object is sent with PUT
+		{name: "replace_puts", method: "PUT", call: func(c *restclient.RestClient) (int, restclient.RestResponse, error) {
+			return c.CallReplaceMethod(context.Background(), "user/7", nil, body)
+		}},
+		{name: "replace_error", method: "PUT", wantErr: true, call: func(c *restclient.RestClient) (int, restclient.RestResponse, error) {
+			return c.CallReplaceMethod(context.Background(), "user/7", nil, body)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := restclienttest.MockResponse{ExpectedMethod: tt.method, ExpectedURL: "user/7", StatusCode: 200, Response: restclient.RestResponse{Status: "success", Message: "user updated"}}
+			if tt.wantErr {
+				response = restclienttest.MockResponse{ExpectedMethod: tt.method, ExpectedURL: "user/7", StatusCode: 400, Err: &restclient.APIError{StatusCode: 400, Message: "no group found with id 999"}}
+			}
+			c, err := restclienttest.NewMockedRestClient(t, []restclienttest.MockResponse{response})
+			if err != nil {
+				t.Fatal(err)
+			}
+			statusCode, got, err := tt.call(c)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if statusCode != response.StatusCode {
+				t.Errorf("statusCode = %d, want %d", statusCode, response.StatusCode)
+			}
+			if !tt.wantErr && got.Message != "user updated" {
+				t.Errorf("got = %#v, want the response", got)
+			}
+			if tt.wantErr && !reflect.DeepEqual(got, restclient.RestResponse{}) {
+				t.Errorf("got = %#v, want an empty response on error", got)
+			}
+		})
+	}
+}
+
+func TestSleep(t *testing.T) {
+	if err := restclient.Sleep(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("sleep() error = %v, want nil", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	if err := restclient.Sleep(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("sleep() error = %v, want %v", err, context.Canceled)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("sleep() did not return when ctx is done, elapsed %s", elapsed)
+	}
+}
+
+func TestRestClient_Wait(t *testing.T) {
+	defer func(interval time.Duration) { *restclient.JobPollInterval = interval }(*restclient.JobPollInterval)
+	*restclient.JobPollInterval = time.Millisecond
+	job := func(status string) restclient.RestResponse {
+		return restclient.RestResponse{Status: restclient.StatusSuccess, Output: map[string]any{"id": 12, "status": status}}
+	}
+	notFound := &restclient.APIError{StatusCode: 200, Status: restclient.StatusError, Message: "job not found"}
+
+	tests := []struct {
+		name      string
+		responses []restclienttest.MockResponse
+		want      int
+		wantErr   bool
+	}{
+		{name: "success", responses: []restclienttest.MockResponse{{ExpectedMethod: "GET", ExpectedURL: "job/12", StatusCode: 200, Response: job("running")}, {ExpectedMethod: "GET", ExpectedURL: "job/12", StatusCode: 200, Response: job("success")}}, want: 200},
+		{name: "waiting_approval", responses: []restclienttest.MockResponse{{ExpectedMethod: "GET", ExpectedURL: "job/12", StatusCode: 200, Response: job("approve")}}, want: 200},
+		{name: "failed", responses: []restclienttest.MockResponse{{ExpectedMethod: "GET", ExpectedURL: "job/12", StatusCode: 200, Response: job("failed")}}, want: 200, wantErr: true},
+		{name: "not_found", responses: []restclienttest.MockResponse{{ExpectedMethod: "GET", ExpectedURL: "job/12", StatusCode: 200, Err: notFound}}, want: 200, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := restclienttest.NewMockedRestClient(t, tt.responses)
+			if err != nil {
+				panic(err)
+			}
+			got, _, err := c.Wait(context.Background(), "12")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RestClient.Wait() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RestClient.Wait() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
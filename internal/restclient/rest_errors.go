@@ -0,0 +1,143 @@
+package restclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrorType classifies the error reported in a RestResponse.
+type ErrorType string
+
+// Error types reported in RestResponse.ErrorType.
+const (
+	ErrorTypeNone                ErrorType = ""
+	ErrorTypeHTTP                ErrorType = "http"
+	ErrorTypeDecodeJSON          ErrorType = "bad_response_decode_json"
+	ErrorTypeDecodeInterface     ErrorType = "bad_response_decode_interface"
+	ErrorTypeDecodeRaw           ErrorType = "bad_response_decode_raw"
+	ErrorTypeRestError           ErrorType = "rest_error"
+	ErrorTypeStatusCodeError     ErrorType = "statuscode_error"
+	ErrorTypeUnexpectedRecordNum ErrorType = "unexpected_record_count"
+)
+
+// Sentinel errors, to be used with errors.Is on errors returned by RestClient.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrConflict     = errors.New("conflict")
+)
+
+// APIError is returned when Ansible Forms reports an error, with an HTTP status code or in the response body.
+type APIError struct {
+	// StatusCode is the HTTP status code
+	StatusCode int
+	// Method and Path identify the request, Path is relative to the API root
+	Method string
+	Path   string
+	// Status, Message and Detail are Ansible Forms status, message and data.error fields, when present
+	Status  string
+	Message string
+	Detail  string
+	// Code is the error code, when the response includes an error object
+	Code string
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	var details []string
+	for _, detail := range []string{e.Message, e.Detail} {
+		if detail != "" {
+			details = append(details, detail)
+		}
+	}
+	if e.Code != "" {
+		details = append(details, "code "+e.Code)
+	}
+	if len(details) == 0 {
+		details = append(details, "no details")
+	}
+	request := strings.TrimSpace(e.Method + " " + e.Path)
+	if request == "" {
+		return fmt.Sprintf("Ansible Forms error, statusCode %d: %s", e.StatusCode, strings.Join(details, ", "))
+	}
+	return fmt.Sprintf("Ansible Forms error on %s, statusCode %d: %s", request, e.StatusCode, strings.Join(details, ", "))
+}
+
+// Is allows errors.Is to match the sentinel errors based on the status code.
+// Ansible Forms may also report a missing object with status "error" and statusCode 200.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || (e.Status == StatusError && strings.Contains(strings.ToLower(e.Message), "not found"))
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	}
+	return false
+}
+
+// RequestError is returned when the request could not be sent, the response could not be read or decoded,
+// or the number of records is not the expected one.
+// It wraps the underlying error, eg context.Canceled.
+type RequestError struct {
+	ErrorType  ErrorType
+	StatusCode int
+	Method     string
+	Path       string
+	Err        error
+}
+
+// Error implements the error interface.
+func (e *RequestError) Error() string {
+	request := strings.TrimSpace(e.Method + " " + e.Path)
+	if request == "" {
+		return fmt.Sprintf("%s error, statusCode %d: %s", e.ErrorType, e.StatusCode, e.Err)
+	}
+	return fmt.Sprintf("%s error on %s, statusCode %d: %s", e.ErrorType, request, e.StatusCode, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound reports whether err is an APIError for a resource that does not exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsUnauthorized reports whether err is an APIError for invalid or missing credentials.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsForbidden reports whether err is an APIError for an operation the user is not allowed to perform.
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsConflict reports whether err is an APIError for a conflict, eg an object that already exists.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// withRequest adds the request method and path to APIError and RequestError.
+func withRequest(err error, method string, path string) error {
+	var apiError *APIError
+	if errors.As(err, &apiError) {
+		apiError.Method = method
+		apiError.Path = path
+	}
+	var requestError *RequestError
+	if errors.As(err, &requestError) {
+		requestError.Method = method
+		requestError.Path = path
+	}
+	return err
+}
@@ -0,0 +1,86 @@
+package restclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		notFound       bool
+		unauthorized   bool
+		forbidden      bool
+		conflict       bool
+		wantAPIMessage string
+	}{
+		{name: "not_found", err: &APIError{StatusCode: 404, Message: "job not found"}, notFound: true, wantAPIMessage: "job not found"},
+		{name: "unauthorized", err: &APIError{StatusCode: 401}, unauthorized: true},
+		{name: "forbidden", err: &APIError{StatusCode: 403}, forbidden: true},
+		{name: "conflict_wrapped", err: fmt.Errorf("creating credential: %w", &APIError{StatusCode: 409, Message: "duplicate"}), conflict: true, wantAPIMessage: "duplicate"},
+		{name: "server_error", err: &APIError{StatusCode: 500}},
+		{name: "request_error", err: &RequestError{ErrorType: ErrorTypeHTTP, StatusCode: 404, Err: errors.New("connection reset")}},
+		{name: "nil", err: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNotFound(tt.err); got != tt.notFound {
+				t.Errorf("IsNotFound() = %v, want %v", got, tt.notFound)
+			}
+			if got := IsUnauthorized(tt.err); got != tt.unauthorized {
+				t.Errorf("IsUnauthorized() = %v, want %v", got, tt.unauthorized)
+			}
+			if got := IsForbidden(tt.err); got != tt.forbidden {
+				t.Errorf("IsForbidden() = %v, want %v", got, tt.forbidden)
+			}
+			if got := IsConflict(tt.err); got != tt.conflict {
+				t.Errorf("IsConflict() = %v, want %v", got, tt.conflict)
+			}
+			var apiError *APIError
+			if errors.As(tt.err, &apiError) && apiError.Message != tt.wantAPIMessage {
+				t.Errorf("APIError.Message = %s, want %s", apiError.Message, tt.wantAPIMessage)
+			}
+		})
+	}
+}
+
+func TestRestClient_unmarshalResponse_errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusCode   int
+		responseJSON string
+		want         *APIError
+	}{
+		{name: "ansible_forms_error", statusCode: 404, responseJSON: `{"status":"error","message":"job not found","data":{"output":"","error":"no job with id 12"}}`,
+			want: &APIError{StatusCode: 404, Method: "GET", Path: "job/12", Status: "error", Message: "job not found", Detail: "no job with id 12"}},
+		{name: "error_field", statusCode: 401, responseJSON: `{"error":"Unauthorized"}`,
+			want: &APIError{StatusCode: 401, Method: "GET", Path: "job/12", Detail: "Unauthorized"}},
+		{name: "not_json", statusCode: 404, responseJSON: `<html>Cannot GET /api/v1/job/12</html>`,
+			want: &APIError{StatusCode: 404, Method: "GET", Path: "job/12", Message: "unable to decode response"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &RestClient{}
+			_, _, err := c.unmarshalResponse(context.Background(), tt.statusCode, []byte(tt.responseJSON), nil)
+			err = withRequest(err, "GET", "job/12")
+			var got *APIError
+			if !errors.As(err, &got) {
+				t.Fatalf("RestClient.unmarshalResponse() error = %#v, want *APIError", err)
+			}
+			if *got != *tt.want {
+				t.Errorf("RestClient.unmarshalResponse() error = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestError_Unwrap(t *testing.T) {
+	c := &RestClient{}
+	_, _, err := c.unmarshalResponse(context.Background(), -1, nil, context.Canceled)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RestClient.unmarshalResponse() error = %v, want to wrap %v", err, context.Canceled)
+	}
+}
@@ -1,128 +1,178 @@
 package restclient
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/mitchellh/mapstructure"
 )
 
-// RestError maps the REST error structure
-type RestError struct {
-	Code    string
-	Message string
-	Target  string
-}
+// Ansible Forms response statuses.
+const (
+	StatusSuccess = "success"
+	StatusError   = "error"
+)
 
-// RestResponse to return a list of records (can be empty) and/or errors.
+// RestResponse is the decoded Ansible Forms response.
+//
+// Ansible Forms wraps most responses in an envelope: {"status": "success", "message": "...", "data": {"output": ..., "error": ""}}.
+// Output holds data.output, or data when it does not follow the output/error convention, or the whole document when
+// there is no envelope (eg auth/login).
+// Records lists the objects in Output: one record for an object, one record per object for a list.
 type RestResponse struct {
-	NumRecords int `mapstructure:"num_records"`
+	Status     string
+	Message    string
+	Output     any
+	NumRecords int
 	Records    []map[string]any
-	RestError  RestError `mapstructure:"error"`
 	StatusCode int
 	HTTPError  string
-	ErrorType  string
-	Job        map[string]any
-	Jobs       []map[string]any
+	ErrorType  ErrorType
+}
+
+// DecodeOutput decodes Output into output, a pointer to a struct, map or slice using mapstructure tags.
+func (r RestResponse) DecodeOutput(output any) error {
+	return Decode(r.Output, output)
 }
 
-// unmarshalResponse converts the REST response into a structure with a list of 0 or more records.
-// We're doing it in two phases:
-// 1. Unmarshall to intermediate structure, as records may or may not present.
-// 2. Adjust intermediate structure, and decode to final structure.
-func (r *RestClient) unmarshalResponse(statusCode int, responseJSON []byte, httpClientErr error) (int, RestResponse, error) {
-	emptyResponse := RestResponse{
-		NumRecords: 0,
+// Decode decodes a record or any part of a response into output, a pointer to a struct, map or slice using mapstructure tags.
+// Input is weakly typed, as Ansible Forms reports booleans as 0 or 1, and numbers as strings in some places.
+func Decode(input any, output any) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           output,
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(input)
+}
+
+// unmarshalResponse converts the REST response into a RestResponse, with a list of 0 or more records.
+// Errors are reported as *APIError when Ansible Forms reports an error, with an HTTP status code or with status "error"
+// even when the status code is 200, and as *RequestError otherwise.
+func (r *RestClient) unmarshalResponse(ctx context.Context, statusCode int, responseJSON []byte, httpClientErr error) (int, RestResponse, error) {
+	response := RestResponse{
 		Records:    []map[string]any{},
-		RestError:  RestError{},
 		StatusCode: statusCode,
-		HTTPError:  "",
-		ErrorType:  "",
+		ErrorType:  ErrorTypeNone,
 	}
 	if httpClientErr != nil {
-		emptyResponse.HTTPError = httpClientErr.Error()
-		emptyResponse.ErrorType = "http"
-		return statusCode, emptyResponse, httpClientErr
+		response.HTTPError = httpClientErr.Error()
+		response.ErrorType = ErrorTypeHTTP
+		return statusCode, response, &RequestError{ErrorType: ErrorTypeHTTP, StatusCode: statusCode, Err: httpClientErr}
 	}
 
-	// We don't know which fields are present or not, and fields may not be in a record, so just use any
-	var dataMap map[string]any
-	if err := json.Unmarshal(responseJSON, &dataMap); err != nil {
-		tflog.Error(r.ctx, fmt.Sprintf("unable to unmarshall response, this may be expected when statusCode %d >= 300, unmarshall error=%s, response=%#v", statusCode, err, responseJSON))
-		emptyResponse.ErrorType = "bad_response_decode_json"
-		return statusCode, emptyResponse, err
-	}
-	tflog.Debug(r.ctx, fmt.Sprintf("dataMap %#v", dataMap))
-
-	// The returned REST response may or may not contain records.
-	// If records is not present, the contents will show in Other.
-	type restStagedResponse struct {
-		NumRecords int `mapstructure:"num_records"`
-		Records    []map[string]any
-		Error      RestError
-		Job        map[string]any
-		Jobs       []map[string]any
-		Other      map[string]any `mapstructure:",remain"`
+	var document any
+	if len(bytes.TrimSpace(responseJSON)) == 0 && isSuccessStatusCode(statusCode) {
+		// eg DELETE may not return a body
+		document = nil
+	} else if err := json.Unmarshal(responseJSON, &document); err != nil {
+		tflog.Error(ctx, fmt.Sprintf("unable to unmarshall response, this may be expected when statusCode %d >= 300, unmarshall error=%s, response=%s", statusCode, err, r.redactor.ForContext(ctx).JSON(responseJSON)))
+		if statusCode >= 300 {
+			// the body is not JSON, eg an HTML error page, but the status code is still meaningful
+			response.ErrorType = ErrorTypeStatusCodeError
+			return statusCode, response, &APIError{StatusCode: statusCode, Message: "unable to decode response"}
+		}
+		response.ErrorType = ErrorTypeDecodeJSON
+		return statusCode, response, &RequestError{ErrorType: ErrorTypeDecodeJSON, StatusCode: statusCode, Err: err}
 	}
+	tflog.Debug(ctx, fmt.Sprintf("document %v", r.redactor.ForContext(ctx).Value(document)))
 
-	var rawResponse restStagedResponse
-	var metadata mapstructure.Metadata
-	if err := mapstructure.DecodeMetadata(dataMap, &rawResponse, &metadata); err != nil {
-		tflog.Error(r.ctx, fmt.Sprintf("unable to format raw response, this may be expected when statusCode %d >= 300, unmarshall error=%s, response=%#v", statusCode, err, dataMap))
-		emptyResponse.ErrorType = "bad_response_decode_interface"
-		return statusCode, emptyResponse, err
+	var detail string
+	response.Status, response.Message, response.Output, detail = decodeEnvelope(document)
+	records, err := toRecords(response.Output)
+	if err != nil {
+		response.ErrorType = ErrorTypeDecodeInterface
+		return statusCode, response, &RequestError{ErrorType: ErrorTypeDecodeInterface, StatusCode: statusCode, Err: err}
 	}
-
-	tflog.Debug(r.ctx, fmt.Sprintf("rawResponse %#v, metadata %#v", rawResponse, metadata))
-
-	// If Other is present, add it to records.
-	// But ignore it if we already have some records.
-	// Other will always have 1 element called _link, so only do this if Other has more than 1 element
-	// Examples:
-	// {NumRecords:0 Records:[] Error:{Code: Message: Target:} Job:map[] Jobs:[] Other:map[_links:map[self:map[href:/api/cluster/schedules?fields=name%2Cuuid%2Ccron%2Cinterval%2Ctype%2Cscope&name=mytest]]]}
-	// {NumRecords:0 Records:[] Error:{Code: Message: Target:} Job:map[] Jobs:[] Other:map[_links:map[self:map[href:/api/cluster]] certificate:map[_links:map[self:map[href:/api/security/certificates/2f632ea7-92cd-11ed-8f2b-005056b3357c]] uuid:2f632ea7-92cd-11ed-8f2b-005056b3357c] metric:map[duration:PT15S iops:map[other:0 read:0 total:0 write:0] latency:map[other:0 read:0 total:0 write:0] status:ok throughput:map[other:0 read:0 total:0 write:0] timestamp:2023-03-16T18:36:30Z] name:laurentncluster-2 peering_policy:map[authentication_required:true encryption_required:false minimum_passphrase_length:8] san_optimized:false statistics:map[iops_raw:map[other:0 read:0 total:0 write:0] latency_raw:map[other:0 read:0 total:0 write:0] status:ok throughput_raw:map[other:0 read:0 total:0 write:0] timestamp:2023-03-16T18:36:31Z] timezone:map[name:Etc/UTC] uuid:2115008a-92cd-11ed-8f2b-005056b3357c version:map[full:NetApp Release Metropolitan__9.11.1: Sat Dec 10 19:08:07 UTC 2022 generation:9 major:11 minor:1]]}
-	if rawResponse.NumRecords == 0 && len(rawResponse.Records) == 0 && len(rawResponse.Other) > 1 {
-		rawResponse.NumRecords = 1
-		rawResponse.Records = append(rawResponse.Records, rawResponse.Other)
+	response.Records = records
+	response.NumRecords = len(records)
+
+	if response.Status == StatusError {
+		response.ErrorType = ErrorTypeRestError
+		err = &APIError{StatusCode: statusCode, Status: response.Status, Message: response.Message, Detail: detail}
+	} else if !isSuccessStatusCode(statusCode) {
+		response.ErrorType = ErrorTypeStatusCodeError
+		err = &APIError{StatusCode: statusCode, Status: response.Status, Message: response.Message, Detail: detail}
 	}
-
-	var finalResponse RestResponse
-	if err := mapstructure.DecodeMetadata(rawResponse, &finalResponse, &metadata); err != nil {
-		tflog.Error(r.ctx, fmt.Sprintf("unable to format final response - statusCode %d, http err=%#v, decode error=%s, response=%#v", statusCode, httpClientErr, err, rawResponse))
-		emptyResponse.ErrorType = "bad_response_decode_raw"
-		return statusCode, emptyResponse, err
+	if err != nil {
+		tflog.Error(ctx, fmt.Sprintf("Ansible Forms reported an error: %s, output: %v", err, r.redactor.ForContext(ctx).Value(response.Output)))
 	}
+	tflog.Debug(ctx, fmt.Sprintf("response status %s, message %s, num_records %d, error type %s", response.Status, response.Message, response.NumRecords, response.ErrorType))
 
-	// If we reached this point, the only possible errors are a bad HTTP status code and/or a REST error encoded in the paybload
-	finalResponse.StatusCode = statusCode
-	finalResponse, err := r.checkRestErrors(statusCode, finalResponse)
-	tflog.Debug(r.ctx, fmt.Sprintf("finalResponse %#v, metadata %#v", finalResponse, metadata))
-
-	return statusCode, finalResponse, err
+	return statusCode, response, err
 }
 
-// check for statusCode and RestError
-func (r *RestClient) checkRestErrors(statusCode int, response RestResponse) (RestResponse, error) {
-	var err error
-	if response.RestError.Code != "0" && response.RestError.Code != "" {
-		response.ErrorType = "rest_error"
-		err = fmt.Errorf("REST reported error %#v, statusCode: %d", response.RestError, statusCode)
-	} else if err = r.checkStatusCode(statusCode); err != nil {
-		response.ErrorType = "statuscode_error"
+// decodeEnvelope returns the status, message, output and error detail from an Ansible Forms response.
+// document is returned as output when it is not an envelope.
+func decodeEnvelope(document any) (status string, message string, output any, detail string) {
+	envelope, ok := document.(map[string]any)
+	if !ok {
+		return "", "", document, ""
 	}
-	if err != nil {
-		tflog.Error(r.ctx, fmt.Sprintf("checkRestError: %s, statusCode %d, response: %#v", err, statusCode, response))
+	status, isEnvelope := envelope["status"].(string)
+	_, hasMessage := envelope["message"]
+	_, hasData := envelope["data"]
+	if !isEnvelope || !(hasMessage || hasData) {
+		// some errors are reported as {"error": "..."} or {"message": "..."}
+		detail, _ = envelope["error"].(string)
+		message, _ = envelope["message"].(string)
+		return "", message, document, detail
+	}
+	message, _ = envelope["message"].(string)
+	output = envelope["data"]
+	if data, ok := envelope["data"].(map[string]any); ok && isOutputErrorForm(data) {
+		output = data["output"]
+		detail, _ = data["error"].(string)
 	}
 
-	return response, err
+	return status, message, output, detail
 }
 
-// checkStatusCode checks and validates the statusCode
-func (r *RestClient) checkStatusCode(statusCode int) error {
-	if statusCode >= 300 || statusCode < 200 {
-		return fmt.Errorf("statusCode indicates error, without details: %d", statusCode)
+// isOutputErrorForm reports whether data only has output and/or error keys.
+func isOutputErrorForm(data map[string]any) bool {
+	if len(data) == 0 {
+		return false
 	}
+	for key := range data {
+		if key != "output" && key != "error" {
+			return false
+		}
+	}
+	return true
+}
+
+// toRecords lists the objects in output.
+// A list with elements other than objects is not an error, but produces no records.
+func toRecords(output any) ([]map[string]any, error) {
+	switch value := output.(type) {
+	case nil:
+		return []map[string]any{}, nil
+	case map[string]any:
+		return []map[string]any{value}, nil
+	case []any:
+		records := make([]map[string]any, 0, len(value))
+		for _, element := range value {
+			record, ok := element.(map[string]any)
+			if !ok {
+				return []map[string]any{}, nil
+			}
+			records = append(records, record)
+		}
+		return records, nil
+	case string, bool, float64:
+		return []map[string]any{}, nil
+	default:
+		return nil, fmt.Errorf("unexpected output type %T", output)
+	}
+}
 
-	return nil
+// isSuccessStatusCode reports whether statusCode is in the 2xx range.
+func isSuccessStatusCode(statusCode int) bool {
+	return statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices
 }
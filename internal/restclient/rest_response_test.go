@@ -2,125 +2,124 @@ package restclient
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
-
-	"github.com/mitchellh/mapstructure"
 )
 
+// readTestData returns a payload recorded from Ansible Forms, from the testdata directory.
+func readTestData(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
 func TestRestClient_unmarshalResponse(t *testing.T) {
 	type args struct {
 		statusCode    int
+		payload       string
 		responseJSON  []byte
 		httpClientErr error
 	}
-	responseForJSON := map[string]any{
-		"num_records": 1,
-		"records": []map[string]any{
-			{"option": "value"},
-		},
-		"statuscode": 200}
-	response := RestResponse{
-		NumRecords: 1,
-		Records: []map[string]any{
-			{"option": "value"},
-		},
-		StatusCode: 200}
-	responseOthers := RestResponse{
-		NumRecords: 1,
-		Records: []map[string]any{
-			{"_link": "somelink", "option": "value"},
-		},
-		StatusCode: 200}
-	restError := RestError{"123", "", ""}
-	responseRestError := RestResponse{
-		NumRecords: 0,
-		Records:    []map[string]any(nil),
-		RestError:  restError,
-		StatusCode: 400,
-		HTTPError:  "",
-		ErrorType:  "rest_error",
-	}
-	responseStatusCodeError := RestResponse{
-		NumRecords: 0,
-		Records:    []map[string]any(nil),
-		StatusCode: 400,
-		ErrorType:  "statuscode_error",
-	}
-	rawResponseRestError := struct {
-		Error RestError
-	}{
-		Error: restError,
-	}
-	responseOther := map[string]any{"_link": "somelink", "option": "value"}
-
-	rawEmpty := any(nil)
-	emptyJSON, err := json.Marshal(rawEmpty)
-	if err != nil {
-		panic(err)
-	}
-	var responseInterfaceOther map[string]any
-	responseJSON, err := json.Marshal(responseForJSON)
-	if err != nil {
-		panic(err)
-	}
-	responseJSONOther, err := json.Marshal(responseOther)
-	if err != nil {
-		panic(err)
-	}
-	responseJSONRestError, err := json.Marshal(rawResponseRestError)
-	if err != nil {
-		panic(err)
-	}
-	err = mapstructure.Decode(responseOther, &responseInterfaceOther)
-	if err != nil {
-		panic(err)
-	}
-	badData := map[string]string{"num_records": "123"}
-	badJSON, err := json.Marshal(badData)
-	if err != nil {
-		panic(err)
-	}
 	genericError := errors.New("generic error for UT")
+	user1 := map[string]any{"id": float64(1), "username": "admin", "group_id": float64(1)}
+	user2 := map[string]any{"id": float64(2), "username": "operator", "group_id": float64(2)}
 
 	tests := []struct {
-		name    string
-		args    args
-		want    int
-		want1   RestResponse
-		wantErr bool
+		name          string
+		args          args
+		want          int
+		wantStatus    string
+		wantMessage   string
+		wantRecords   []map[string]any
+		wantErrorType ErrorType
+		wantErr       error
 	}{
-		{name: "error_no_json", args: args{}, want: 0, want1: RestResponse{ErrorType: "bad_response_decode_json", Records: []map[string]any{}}, wantErr: true},
-		{name: "error_mismatch_json", args: args{statusCode: 200, responseJSON: badJSON}, want: 200, want1: RestResponse{ErrorType: "bad_response_decode_interface", Records: []map[string]any{}, StatusCode: 200}, wantErr: true},
-		{name: "error_http_error", args: args{httpClientErr: genericError}, want: 0, want1: RestResponse{HTTPError: genericError.Error(), ErrorType: "http", Records: []map[string]any{}}, wantErr: true},
-		{name: "json_unmarshalled", args: args{statusCode: 200, responseJSON: responseJSON}, want: 200, want1: response, wantErr: false},
-		{name: "json_unmarshalled_other", args: args{statusCode: 200, responseJSON: responseJSONOther}, want: 200, want1: responseOthers, wantErr: false},
-		{name: "rest_error", args: args{statusCode: 400, responseJSON: responseJSONRestError}, want: 400, want1: responseRestError, wantErr: true},
-		{name: "status_code_error_1", args: args{statusCode: 400, responseJSON: responseJSONRestError}, want: 400, want1: responseRestError, wantErr: true},
-		{name: "status_code_error_2", args: args{statusCode: 400, responseJSON: emptyJSON}, want: 400, want1: responseStatusCodeError, wantErr: true},
+		{name: "job_create", args: args{statusCode: 200, payload: "job_create.json"}, want: 200, wantStatus: StatusSuccess, wantMessage: "ansible job launched",
+			wantRecords: []map[string]any{{"id": float64(12)}}},
+		{name: "job_delete", args: args{statusCode: 200, payload: "job_delete.json"}, want: 200, wantStatus: StatusSuccess, wantMessage: "job deleted",
+			wantRecords: []map[string]any{}},
+		{name: "user_list", args: args{statusCode: 200, payload: "user_list.json"}, want: 200, wantStatus: StatusSuccess,
+			wantRecords: []map[string]any{user1, user2}},
+		{name: "login_no_envelope", args: args{statusCode: 200, payload: "login.json"}, want: 200,
+			wantRecords: []map[string]any{{"token": "eyJhbGciOiJIUzI1NiJ9.e30.c2lnbmF0dXJl", "refreshtoken": "eyJhbGciOiJIUzI1NiJ9.e30.cmVmcmVzaA"}}},
+		{name: "empty_body", args: args{statusCode: 204}, want: 204, wantRecords: []map[string]any{}},
+		{name: "job_not_found_status_200", args: args{statusCode: 200, payload: "job_not_found.json"}, want: 200, wantStatus: StatusError, wantMessage: "job not found",
+			wantRecords: []map[string]any{}, wantErrorType: ErrorTypeRestError, wantErr: ErrNotFound},
+		{name: "job_not_found_status_404", args: args{statusCode: 404, payload: "job_not_found.json"}, want: 404, wantStatus: StatusError, wantMessage: "job not found",
+			wantRecords: []map[string]any{}, wantErrorType: ErrorTypeRestError, wantErr: ErrNotFound},
+		{name: "unauthorized", args: args{statusCode: 401, payload: "unauthorized.json"}, want: 401,
+			wantRecords: []map[string]any{{"error": "Unauthorized"}}, wantErrorType: ErrorTypeStatusCodeError, wantErr: ErrUnauthorized},
+		{name: "error_no_json", args: args{statusCode: 200, responseJSON: []byte("<html></html>")}, want: 200,
+			wantRecords: []map[string]any{}, wantErrorType: ErrorTypeDecodeJSON, wantErr: &RequestError{}},
+		{name: "error_http_error", args: args{httpClientErr: genericError}, want: 0,
+			wantRecords: []map[string]any{}, wantErrorType: ErrorTypeHTTP, wantErr: genericError},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := &RestClient{
-				ctx: context.Background(),
+			responseJSON := tt.args.responseJSON
+			if tt.args.payload != "" {
+				responseJSON = readTestData(t, tt.args.payload)
 			}
-			got, got1, err := c.unmarshalResponse(tt.args.statusCode, tt.args.responseJSON, tt.args.httpClientErr)
-			if err != nil {
-				fmt.Printf("err: %s\n", err)
+			c := &RestClient{}
+			got, got1, err := c.unmarshalResponse(context.Background(), tt.args.statusCode, responseJSON, tt.args.httpClientErr)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("RestClient.unmarshalResponse() error = %v, wantErr %v", err, tt.wantErr)
 			}
-			if (err != nil) != tt.wantErr {
-				t.Errorf("RestClient.unmarshalResponse() error = %v, wantErr %v", err, tt.wantErr)
-				return
+			var requestError *RequestError
+			if errors.As(tt.wantErr, &requestError) {
+				if !errors.As(err, &requestError) {
+					t.Errorf("RestClient.unmarshalResponse() error = %#v, want a RequestError", err)
+				}
+			} else if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("RestClient.unmarshalResponse() error = %v, want %v", err, tt.wantErr)
 			}
 			if got != tt.want {
 				t.Errorf("RestClient.unmarshalResponse() got = %v, want %v", got, tt.want)
 			}
-			if !reflect.DeepEqual(got1, tt.want1) {
-				t.Errorf("RestClient.unmarshalResponse() got1 = %#v, want %#v", got1, tt.want1)
+			if got1.Status != tt.wantStatus || got1.Message != tt.wantMessage {
+				t.Errorf("RestClient.unmarshalResponse() status, message = %q, %q, want %q, %q", got1.Status, got1.Message, tt.wantStatus, tt.wantMessage)
+			}
+			if got1.ErrorType != tt.wantErrorType {
+				t.Errorf("RestClient.unmarshalResponse() error type = %q, want %q", got1.ErrorType, tt.wantErrorType)
+			}
+			if got1.NumRecords != len(tt.wantRecords) || !reflect.DeepEqual(got1.Records, tt.wantRecords) {
+				t.Errorf("RestClient.unmarshalResponse() records = %d %#v, want %#v", got1.NumRecords, got1.Records, tt.wantRecords)
 			}
 		})
 	}
 }
+
+func TestRestResponse_DecodeOutput(t *testing.T) {
+	c := &RestClient{}
+	_, response, err := c.unmarshalResponse(context.Background(), 200, readTestData(t, "job_get.json"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var job struct {
+		ID        int64  `mapstructure:"id"`
+		Status    string `mapstructure:"status"`
+		Extravars string `mapstructure:"extravars"`
+		Counter   int64  `mapstructure:"counter"`
+		Approval  string `mapstructure:"approval"`
+	}
+	if err = response.DecodeOutput(&job); err != nil {
+		t.Fatal(err)
+	}
+	if job.ID != 12 || job.Status != JobStatusSuccess || job.Extravars != `{"vm_name":"demo"}` || job.Counter != 4 || job.Approval != "" {
+		t.Errorf("RestResponse.DecodeOutput() got = %#v", job)
+	}
+
+	// weakly typed input, Ansible Forms reports some numbers as strings
+	var created struct {
+		ID int64 `mapstructure:"id"`
+	}
+	if err = Decode(map[string]any{"id": "12"}, &created); err != nil || created.ID != 12 {
+		t.Errorf("Decode() got = %#v, err %v", created, err)
+	}
+}
@@ -0,0 +1,99 @@
+// Package restclienttest provides a mocked restclient.Client for Unit Testing.
+package restclienttest
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+)
+
+// MockResponse is used in Unit Testing to mock expected REST responses.
+// It validates that the request matches ExpectedMethod and ExpectedURL, to return the other elements.
+// ExpectedQuery and ExpectedBody are only validated when they are not nil.
+type MockResponse struct {
+	ExpectedMethod string
+	ExpectedURL    string
+	ExpectedQuery  url.Values
+	ExpectedBody   map[string]any
+	StatusCode     int
+	Response       restclient.RestResponse
+	Err            error
+}
+
+// MockTransport returns the expected responses in order, and reports unexpected requests through t.
+// Responses that are not consumed when the test ends are reported as well.
+type MockTransport struct {
+	t         testing.TB
+	mutex     sync.Mutex
+	responses []MockResponse
+}
+
+// NewMockTransport creates a MockTransport for the test t.
+func NewMockTransport(t testing.TB, responses []MockResponse) *MockTransport {
+	t.Helper()
+	transport := &MockTransport{t: t, responses: responses}
+	t.Cleanup(func() {
+		transport.mutex.Lock()
+		defer transport.mutex.Unlock()
+		for _, response := range transport.responses {
+			t.Errorf("expected request was not sent: %s %s", response.ExpectedMethod, response.ExpectedURL)
+		}
+	})
+
+	return transport
+}
+
+// CallAPIMethod validates the request against the next expected response, and returns it.
+func (m *MockTransport) CallAPIMethod(_ context.Context, method string, baseURL string, query *restclient.RestQuery, body map[string]any) (int, restclient.RestResponse, error) {
+	m.t.Helper()
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if len(m.responses) == 0 {
+		m.t.Errorf("unexpected request: %s %s", method, baseURL)
+		return 0, restclient.RestResponse{}, fmt.Errorf("unexpected request: %s %s", method, baseURL)
+	}
+	expectedResponse := m.responses[0]
+	// remove element now that we know it is consumed
+	m.responses = m.responses[1:]
+
+	if expectedResponse.ExpectedMethod != method || expectedResponse.ExpectedURL != baseURL {
+		m.t.Errorf("unexpected request: %s %s, expecting %s %s", method, baseURL, expectedResponse.ExpectedMethod, expectedResponse.ExpectedURL)
+		return 0, restclient.RestResponse{}, fmt.Errorf("unexpected request: %s %s", method, baseURL)
+	}
+	if expectedResponse.ExpectedQuery != nil {
+		values := url.Values{}
+		if query != nil {
+			values = query.Values
+		}
+		if !reflect.DeepEqual(values, expectedResponse.ExpectedQuery) {
+			m.t.Errorf("unexpected query for %s %s: %v, expecting %v", method, baseURL, values, expectedResponse.ExpectedQuery)
+		}
+	}
+	if expectedResponse.ExpectedBody != nil && !reflect.DeepEqual(body, expectedResponse.ExpectedBody) {
+		m.t.Errorf("unexpected body for %s %s: %#v, expecting %#v", method, baseURL, body, expectedResponse.ExpectedBody)
+	}
+
+	return expectedResponse.StatusCode, expectedResponse.Response, expectedResponse.Err
+}
+
+// NewMockedRestClient is used in Unit Testing to mock expected REST responses.
+func NewMockedRestClient(t testing.TB, responses []MockResponse) (*restclient.RestClient, error) {
+	t.Helper()
+	cxProfile := restclient.ConnectionProfile{
+		Hostname: "",
+		Username: "",
+		Password: "",
+	}
+	newRestClient, err := restclient.NewClient(context.Background(), cxProfile, "resource/version", 600)
+	if err != nil {
+		return nil, err
+	}
+	newRestClient.SetTransport(NewMockTransport(t, responses))
+
+	return newRestClient, nil
+}
@@ -0,0 +1,62 @@
+package restclienttest
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"terraform-provider-ansible-forms/internal/restclient"
+)
+
+// recordingT records errors reported by MockTransport, instead of failing the test.
+type recordingT struct {
+	testing.TB
+	errors   []string
+	cleanups []func()
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) Cleanup(f func()) {
+	r.cleanups = append(r.cleanups, f)
+}
+
+func TestMockTransport(t *testing.T) {
+	expected := MockResponse{ExpectedMethod: "POST", ExpectedURL: "job/", ExpectedQuery: url.Values{"fields": {"id"}}, ExpectedBody: map[string]any{"formName": "Demo Form"}, StatusCode: 200}
+	query := &restclient.RestQuery{Values: url.Values{"fields": {"id"}}}
+	tests := []struct {
+		name       string
+		responses  []MockResponse
+		method     string
+		baseURL    string
+		query      *restclient.RestQuery
+		body       map[string]any
+		wantErrors int
+	}{
+		{name: "match", responses: []MockResponse{expected}, method: "POST", baseURL: "job/", query: query, body: map[string]any{"formName": "Demo Form"}},
+		{name: "method_mismatch", responses: []MockResponse{expected}, method: "PATCH", baseURL: "job/", query: query, body: map[string]any{"formName": "Demo Form"}, wantErrors: 1},
+		{name: "url_mismatch", responses: []MockResponse{expected}, method: "POST", baseURL: "job/12", query: query, body: map[string]any{"formName": "Demo Form"}, wantErrors: 1},
+		{name: "query_mismatch", responses: []MockResponse{expected}, method: "POST", baseURL: "job/", body: map[string]any{"formName": "Demo Form"}, wantErrors: 1},
+		{name: "body_mismatch", responses: []MockResponse{expected}, method: "POST", baseURL: "job/", query: query, body: map[string]any{"formName": "Other Form"}, wantErrors: 1},
+		{name: "unexpected_request", method: "GET", baseURL: "job/12", wantErrors: 1},
+		{name: "request_not_sent", responses: []MockResponse{expected, expected}, method: "POST", baseURL: "job/", query: query, body: map[string]any{"formName": "Demo Form"}, wantErrors: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &recordingT{TB: t}
+			transport := NewMockTransport(recorder, tt.responses)
+			_, _, _ = transport.CallAPIMethod(context.Background(), tt.method, tt.baseURL, tt.query, tt.body)
+			for _, cleanup := range recorder.cleanups {
+				cleanup()
+			}
+			if len(recorder.errors) != tt.wantErrors {
+				t.Errorf("MockTransport reported %d errors %v, want %d", len(recorder.errors), recorder.errors, tt.wantErrors)
+			}
+		})
+	}
+}
@@ -20,11 +20,17 @@ type ErrorHandler struct {
 // NewErrorHandler creates an error handler based on current context and TF diagnostics
 func NewErrorHandler(ctx context.Context, diags *diag.Diagnostics) *ErrorHandler {
 	name := "error_handler"
+	subCtx := tflog.NewSubsystem(ctx, name, tflog.WithAdditionalLocationOffset(1))
+	// subsystems do not inherit the masks of the root logger
+	if secrets := contextSecrets(ctx); len(secrets) != 0 {
+		subCtx = tflog.SubsystemMaskAllFieldValuesStrings(subCtx, name, secrets...)
+		subCtx = tflog.SubsystemMaskMessageStrings(subCtx, name, secrets...)
+	}
 	return &ErrorHandler{
 		Ctx:    ctx,
 		diags:  diags,
 		name:   name,
-		subCtx: tflog.NewSubsystem(ctx, name, tflog.WithAdditionalLocationOffset(1)),
+		subCtx: subCtx,
 	}
 }
 